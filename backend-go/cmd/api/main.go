@@ -3,15 +3,23 @@ package main
 import (
 	"context"
 	"log"
+	"time"
 
 	"github.com/joho/godotenv"
 
+	"unichance-backend-go/internal/admin"
+	"unichance-backend-go/internal/admissions"
 	"unichance-backend-go/internal/auth"
 	"unichance-backend-go/internal/config"
+	"unichance-backend-go/internal/currency"
 	"unichance-backend-go/internal/db"
 	httpRouter "unichance-backend-go/internal/http"
+	"unichance-backend-go/internal/mail"
+	"unichance-backend-go/internal/policies"
 	"unichance-backend-go/internal/profile"
 	"unichance-backend-go/internal/programs"
+	"unichance-backend-go/internal/ratelimit"
+	"unichance-backend-go/internal/search"
 	"unichance-backend-go/internal/universities"
 )
 
@@ -30,25 +38,66 @@ func main() {
 	defer pool.Close()
 
 	// auth
-	authSvc := auth.Service{DB: pool, JwtSecret: cfg.JwtSecret}
-	authH := auth.Handler{Svc: authSvc}
+	mailer := mail.New(mail.Config{
+		SMTPHost:     cfg.SMTPHost,
+		SMTPPort:     cfg.SMTPPort,
+		SMTPUser:     cfg.SMTPUser,
+		SMTPPassword: cfg.SMTPPassword,
+		From:         cfg.MailFrom,
+		Dir:          cfg.MailDir,
+	})
+	authSvc := auth.Service{
+		DB:         pool,
+		JwtSecret:  cfg.JwtSecret,
+		AccessTTL:  cfg.AccessTokenTTL,
+		RefreshTTL: cfg.RefreshTokenTTL,
+		Mailer:     mailer,
+		AppURL:     cfg.AppURL,
+	}
+	// rate limits + login lockout (in-memory: бір instance үшін)
+	limits := ratelimit.NewMemoryStore()
+	authH := auth.Handler{Svc: authSvc, Guard: auth.Guard{
+		Store:   limits,
+		Account: ratelimit.Per(10, 15*time.Minute),
+		Lockout: ratelimit.DefaultLockout(limits),
+		Audit:   pool,
+	}}
+
+	// exchange rates (exchange_rates, cached)
+	curRepo := currency.Repo{DB: pool}
+	fx := currency.NewService(curRepo)
 
 	// programs
-	progRepo := programs.Repo{DB: pool}
-	progH := programs.Handler{Repo: progRepo}
+	progRepo := programs.Repo{DB: pool, FX: fx}
+	admRepo := admissions.Repo{DB: pool}
+	progH := programs.Handler{Repo: progRepo, Admissions: admRepo}
 	uniRepo := universities.Repo{DB: pool}
 	uniH := universities.Handler{Repo: uniRepo}
 
+	// scoring policy
+	policy, err := policies.Load(context.Background(), policies.Repo{DB: pool}, cfg.ScoringPolicyFile, cfg.ScoringPolicy)
+	if err != nil {
+		log.Fatal(err)
+	}
+	log.Println("scoring policy: " + policy.ID())
+
 	// profile + scoring endpoints
 	profRepo := profile.Repo{DB: pool}
-	profH := profile.Handler{Repo: profRepo, DB: pool}
+	profH := profile.Handler{Repo: profRepo, Admissions: admRepo, Programs: progRepo, Policy: policy, FX: fx, DB: pool}
+	progH.Scorer = profH // GET /programs?with_scores=true
 
 	e := httpRouter.NewRouter(httpRouter.Deps{
 		AuthHandler:         authH,
 		ProgramsHandler:     progH,
 		ProfileHandler:      profH,
 		JwtSecret:           cfg.JwtSecret,
+		TokenVersions:       authSvc,
+		RateLimits:          limits,
+		TrustProxy:          cfg.TrustProxy,
 		UniversitiesHandler: uniH,
+		CurrenciesHandler:   currency.Handler{Repo: curRepo},
+		SearchHandler:       search.Handler{Repo: search.Repo{DB: pool}},
+		AdminHandler:        admin.Handler{Repo: admin.Repo{DB: pool}},
 	})
 
 	log.Println("api listening on :" + cfg.Port)
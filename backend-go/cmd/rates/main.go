@@ -0,0 +1,57 @@
+// Command rates loads exchange rates into exchange_rates.
+//
+//	go run ./cmd/rates -file seed/exchange_rates.csv
+//	go run ./cmd/rates -file eurofxref-hist.xml
+package main
+
+import (
+	"context"
+	"flag"
+	"log"
+	"os"
+	"strings"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/joho/godotenv"
+
+	"unichance-backend-go/internal/currency"
+)
+
+func main() {
+	file := flag.String("file", "seed/exchange_rates.csv", "CSV (date,base,quote,rate[,source]) or ECB XML file")
+	flag.Parse()
+
+	_ = godotenv.Load(".env")
+	dbURL := os.Getenv("DATABASE_URL")
+	if dbURL == "" {
+		log.Fatal("DATABASE_URL required")
+	}
+
+	f, err := os.Open(*file)
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer f.Close()
+
+	var pairs []currency.Pair
+	if strings.HasSuffix(strings.ToLower(*file), ".xml") {
+		pairs, err = currency.ParseECB(f)
+	} else {
+		pairs, err = currency.ParseCSV(f)
+	}
+	if err != nil {
+		log.Fatalf("%s: %v", *file, err)
+	}
+
+	pool, err := pgxpool.New(context.Background(), dbURL)
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer pool.Close()
+
+	n, err := currency.Repo{DB: pool}.Upsert(context.Background(), pairs)
+	if err != nil {
+		log.Fatal(err)
+	}
+	log.Printf("exchange rates loaded: %d\n", n)
+}
@@ -0,0 +1,102 @@
+package main
+
+import (
+	"context"
+	"encoding/csv"
+	"log"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+func programKey(uniName, title, level string) string {
+	return uniName + "|" + title + "|" + level
+}
+
+func parseFloatPtr(s string) *float64 {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return nil
+	}
+	v, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		return nil
+	}
+	return &v
+}
+
+// seedAdmissionStats loads seed/admission_stats.csv (optional).
+// Rows are matched to programs by university name + title + degree level;
+// re-running the seed updates the (program, year) row instead of duplicating it.
+func seedAdmissionStats(ctx context.Context, pool *pgxpool.Pool, progMap, srcMap map[string]string) {
+	f, err := os.Open("seed/admission_stats.csv")
+	if err != nil {
+		log.Printf("admission_stats.csv not found, skip: %v", err)
+		return
+	}
+	defer f.Close()
+
+	cr := csv.NewReader(f)
+	cr.FieldsPerRecord = -1
+	rows, err := cr.ReadAll()
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	inserted := 0
+	// header: university_name,program_title,degree_level,year,applicants,admitted,enrolled,acceptance_rate,avg_gpa,avg_ielts,avg_toefl,avg_sat,source_code
+	for i := 1; i < len(rows); i++ {
+		r := rows[i]
+		if len(r) < 13 {
+			continue
+		}
+
+		progID := progMap[programKey(strings.TrimSpace(r[0]), strings.TrimSpace(r[1]), strings.TrimSpace(r[2]))]
+		if progID == "" {
+			log.Printf("admission_stats row %d: program not found, skip", i+1)
+			continue
+		}
+
+		year := parseIntPtr(r[3])
+		if year == nil {
+			continue
+		}
+
+		var sourceID *string
+		if code := strings.TrimSpace(r[12]); code != "" {
+			if id, ok := srcMap[code]; ok {
+				sourceID = &id
+			}
+		}
+
+		_, err := pool.Exec(ctx, `
+      INSERT INTO admission_stats(
+        program_id, source_id, year, applicants, admitted, enrolled,
+        acceptance_rate, avg_gpa, avg_ielts, avg_toefl, avg_sat
+      ) VALUES ($1,$2,$3,$4,$5,$6,$7,$8,$9,$10,$11)
+      ON CONFLICT (program_id, year) DO UPDATE SET
+        source_id=EXCLUDED.source_id,
+        applicants=EXCLUDED.applicants,
+        admitted=EXCLUDED.admitted,
+        enrolled=EXCLUDED.enrolled,
+        acceptance_rate=EXCLUDED.acceptance_rate,
+        avg_gpa=EXCLUDED.avg_gpa,
+        avg_ielts=EXCLUDED.avg_ielts,
+        avg_toefl=EXCLUDED.avg_toefl,
+        avg_sat=EXCLUDED.avg_sat,
+        updated_at=NOW()
+    `, progID, sourceID, *year,
+			parseIntPtr(r[4]), parseIntPtr(r[5]), parseIntPtr(r[6]),
+			parseFloatPtr(r[7]), parseFloatPtr(r[8]), parseFloatPtr(r[9]),
+			parseIntPtr(r[10]), parseIntPtr(r[11]),
+		)
+		if err != nil {
+			log.Fatal(err)
+		}
+		inserted++
+	}
+
+	log.Printf("seed admission_stats done: %d\n", inserted)
+}
@@ -0,0 +1,79 @@
+package main
+
+import (
+	"context"
+	"encoding/csv"
+	"log"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// seedDeadlines loads seed/program_deadlines.csv (optional).
+// Rows are matched to programs like admission_stats; (program, intake, kind) is upserted.
+func seedDeadlines(ctx context.Context, pool *pgxpool.Pool, progMap, srcMap map[string]string) {
+	f, err := os.Open("seed/program_deadlines.csv")
+	if err != nil {
+		log.Printf("program_deadlines.csv not found, skip: %v", err)
+		return
+	}
+	defer f.Close()
+
+	cr := csv.NewReader(f)
+	cr.FieldsPerRecord = -1
+	rows, err := cr.ReadAll()
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	inserted := 0
+	// header: university_name,program_title,degree_level,intake,kind,deadline,note,source_code
+	for i := 1; i < len(rows); i++ {
+		r := rows[i]
+		if len(r) < 8 {
+			continue
+		}
+
+		progID := progMap[programKey(strings.TrimSpace(r[0]), strings.TrimSpace(r[1]), strings.TrimSpace(r[2]))]
+		if progID == "" {
+			log.Printf("program_deadlines row %d: program not found, skip", i+1)
+			continue
+		}
+
+		intake := strings.TrimSpace(r[3])
+		kind := strings.TrimSpace(r[4])
+		if kind == "" {
+			kind = "application"
+		}
+		deadline, err := time.Parse("2006-01-02", strings.TrimSpace(r[5]))
+		if intake == "" || err != nil {
+			log.Printf("program_deadlines row %d: intake/deadline required, skip", i+1)
+			continue
+		}
+
+		var sourceID *string
+		if code := strings.TrimSpace(r[7]); code != "" {
+			if id, ok := srcMap[code]; ok {
+				sourceID = &id
+			}
+		}
+
+		_, err = pool.Exec(ctx, `
+      INSERT INTO program_deadlines(program_id, source_id, intake, kind, deadline, note)
+      VALUES ($1,$2,$3,$4,$5,NULLIF($6,''))
+      ON CONFLICT (program_id, intake, kind) DO UPDATE SET
+        source_id=EXCLUDED.source_id,
+        deadline=EXCLUDED.deadline,
+        note=EXCLUDED.note,
+        updated_at=NOW()
+    `, progID, sourceID, intake, kind, deadline, strings.TrimSpace(r[6]))
+		if err != nil {
+			log.Fatal(err)
+		}
+		inserted++
+	}
+
+	log.Printf("seed program_deadlines done: %d\n", inserted)
+}
@@ -0,0 +1,79 @@
+package main
+
+import (
+	"context"
+	"encoding/csv"
+	"log"
+	"os"
+	"strings"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"unichance-backend-go/internal/currency"
+)
+
+// seedLivingCosts loads seed/living_costs.csv (optional).
+// An empty city is the country-wide default used when the university's city has no row.
+func seedLivingCosts(ctx context.Context, pool *pgxpool.Pool, srcMap map[string]string, currencies map[string]bool) {
+	f, err := os.Open("seed/living_costs.csv")
+	if err != nil {
+		log.Printf("living_costs.csv not found, skip: %v", err)
+		return
+	}
+	defer f.Close()
+
+	cr := csv.NewReader(f)
+	cr.FieldsPerRecord = -1
+	rows, err := cr.ReadAll()
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	inserted := 0
+	// header: country_code,city,amount_year,currency,source_code
+	for i := 1; i < len(rows); i++ {
+		r := rows[i]
+		if len(r) < 5 {
+			continue
+		}
+
+		country := strings.ToUpper(strings.TrimSpace(r[0]))
+		amount := parseFloatPtr(r[2])
+		cur := currency.Normalize(r[3])
+		if country == "" || amount == nil || cur == "" {
+			log.Printf("living_costs row %d: country/amount/currency required, skip", i+1)
+			continue
+		}
+		if !currencies[cur] {
+			log.Printf("living_costs row %d: unknown currency %q, skip", i+1, cur)
+			continue
+		}
+
+		var city *string
+		if s := strings.TrimSpace(r[1]); s != "" {
+			city = &s
+		}
+		var sourceID *string
+		if code := strings.TrimSpace(r[4]); code != "" {
+			if id, ok := srcMap[code]; ok {
+				sourceID = &id
+			}
+		}
+
+		_, err := pool.Exec(ctx, `
+      INSERT INTO city_living_costs(country_code, city, amount_year, currency, source_id)
+      VALUES ($1,$2,$3,$4,$5)
+      ON CONFLICT (country_code, lower(COALESCE(city, ''))) DO UPDATE SET
+        amount_year=EXCLUDED.amount_year,
+        currency=EXCLUDED.currency,
+        source_id=EXCLUDED.source_id,
+        updated_at=NOW()
+    `, country, city, *amount, cur, sourceID)
+		if err != nil {
+			log.Fatal(err)
+		}
+		inserted++
+	}
+
+	log.Printf("seed living_costs done: %d\n", inserted)
+}
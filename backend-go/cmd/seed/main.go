@@ -11,6 +11,8 @@ import (
 
 	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/joho/godotenv"
+
+	"unichance-backend-go/internal/currency"
 )
 
 func parseTime(s string) *time.Time {
@@ -332,6 +334,14 @@ func main() {
 
 	}
 
+	// ISO 4217 кодтары (019_currencies.sql)
+	currencies, err := currency.Repo{DB: pool}.Codes(ctx)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	progMap := map[string]string{} // university|title|level -> id
+
 	// header:
 	// university_name,title,degree_level,field,language,tuition_amount,tuition_currency,has_scholarship,scholarship_type,scholarship_percent_min,scholarship_percent_max,description,data_updated_at
 	for i := 1; i < len(pRows); i++ {
@@ -356,7 +366,11 @@ func main() {
 			v, _ := strconv.ParseFloat(r[5], 64)
 			tuition = &v
 		}
-		currency := strings.TrimSpace(r[6])
+		cur := currency.Normalize(r[6])
+		if cur != "" && !currencies[cur] {
+			log.Printf("programs row %d: unknown tuition_currency %q, skip", i+1, cur)
+			continue
+		}
 
 		hasSch := strings.TrimSpace(r[7]) == "true"
 		schType := strings.TrimSpace(r[8])
@@ -375,21 +389,28 @@ func main() {
 		desc := strings.TrimSpace(r[11])
 		updated := parseTime(r[12])
 
-		_, err := pool.Exec(ctx, `
+		var progID string
+		err := pool.QueryRow(ctx, `
       INSERT INTO programs(
         university_id,title,degree_level,field,language,
         tuition_amount,tuition_currency,has_scholarship,scholarship_type,scholarship_percent_min,scholarship_percent_max,
         description,data_updated_at
       ) VALUES (
         $1,$2,$3,$4,$5,
-        $6,NULLIF($7,'')::tuition_currency,$8,NULLIF($9,''),$10,$11,
+        $6,NULLIF($7,''),$8,NULLIF($9,''),$10,$11,
         NULLIF($12,''),$13
       )
-    `, uniID, title, level, field, lang, tuition, currency, hasSch, schType, schMin, schMax, desc, updated)
+      RETURNING id
+    `, uniID, title, level, field, lang, tuition, cur, hasSch, schType, schMin, schMax, desc, updated).Scan(&progID)
 		if err != nil {
 			log.Fatal(err)
 		}
+		progMap[programKey(uniName, title, level)] = progID
 	}
 
+	seedAdmissionStats(ctx, pool, progMap, srcMap)
+	seedLivingCosts(ctx, pool, srcMap, currencies)
+	seedDeadlines(ctx, pool, progMap, srcMap)
+
 	log.Printf("seed done: universities=%d programs=%d\n", len(uniMap), len(pRows)-1)
 }
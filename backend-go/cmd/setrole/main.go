@@ -0,0 +1,46 @@
+// Command setrole changes a user's role. PUT /admin/users/:id/role needs an
+// admin already, so this is how the first admin is created:
+//
+//	go run ./cmd/setrole -email admin@example.com
+//	go run ./cmd/setrole -email someone@example.com -role counselor
+package main
+
+import (
+	"context"
+	"flag"
+	"log"
+	"os"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/joho/godotenv"
+
+	"unichance-backend-go/internal/auth"
+	"unichance-backend-go/internal/middleware"
+)
+
+func main() {
+	email := flag.String("email", "", "account email (must already be registered)")
+	role := flag.String("role", middleware.RoleAdmin, "student | counselor | admin")
+	flag.Parse()
+	if *email == "" {
+		log.Fatal("-email required")
+	}
+
+	_ = godotenv.Load(".env")
+	dbURL := os.Getenv("DATABASE_URL")
+	if dbURL == "" {
+		log.Fatal("DATABASE_URL required")
+	}
+
+	pool, err := pgxpool.New(context.Background(), dbURL)
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer pool.Close()
+
+	u, err := auth.Service{DB: pool}.SetRoleByEmail(context.Background(), *email, *role)
+	if err != nil {
+		log.Fatalf("%s: %v", *email, err)
+	}
+	log.Printf("%s (%s) is now %s\n", u.Email, u.ID, u.Role)
+}
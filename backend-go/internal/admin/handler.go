@@ -0,0 +1,133 @@
+package admin
+
+import (
+  "encoding/json"
+  "errors"
+  "net/http"
+  "strconv"
+  "time"
+
+  "github.com/labstack/echo/v4"
+
+  "unichance-backend-go/internal/middleware"
+)
+
+const (
+  DefaultLimit = 50
+  MaxLimit = 200
+)
+
+// Handler — /admin/<resource> CRUD. Update/Delete need the updated_at the
+// client last saw (body or ?updated_at=); a stale value gets 409 with the current row.
+type Handler struct {
+  Repo Repo
+}
+
+// List — GET /admin/:resource?limit=&offset=&<filter>=
+func (h Handler) List(name string) echo.HandlerFunc {
+  return func(c echo.Context) error {
+    res := resources[name]
+    limit, _ := strconv.Atoi(c.QueryParam("limit"))
+    if limit <= 0 { limit = DefaultLimit }
+    if limit > MaxLimit { limit = MaxLimit }
+    offset, _ := strconv.Atoi(c.QueryParam("offset"))
+    if offset < 0 { offset = 0 }
+
+    filters := map[string]string{}
+    for _, f := range res.filters { filters[f] = c.QueryParam(f) }
+
+    items, err := h.Repo.List(c.Request().Context(), res, filters, limit, offset)
+    if err != nil { return writeErr(c, err) }
+    return c.JSON(http.StatusOK, map[string]any{"items": items, "limit": limit, "offset": offset})
+  }
+}
+
+// Get — GET /admin/:resource/:id
+func (h Handler) Get(name string) echo.HandlerFunc {
+  return func(c echo.Context) error {
+    row, err := h.Repo.Get(c.Request().Context(), resources[name], c.Param("id"))
+    if err != nil { return writeErr(c, err) }
+    return c.JSON(http.StatusOK, map[string]any{"item": row})
+  }
+}
+
+// Create — POST /admin/:resource
+func (h Handler) Create(name string) echo.HandlerFunc {
+  return func(c echo.Context) error {
+    body, err := decodeBody(c)
+    if err != nil { return c.JSON(http.StatusBadRequest, map[string]string{"error":"bad body"}) }
+    row, err := h.Repo.Create(c.Request().Context(), resources[name], body, actor(c))
+    if err != nil { return writeErr(c, err) }
+    return c.JSON(http.StatusCreated, map[string]any{"item": row})
+  }
+}
+
+// Update — PATCH /admin/:resource/:id {..., updated_at}
+func (h Handler) Update(name string) echo.HandlerFunc {
+  return func(c echo.Context) error {
+    body, err := decodeBody(c)
+    if err != nil { return c.JSON(http.StatusBadRequest, map[string]string{"error":"bad body"}) }
+    var v string
+    if raw, ok := body["updated_at"]; ok { _ = json.Unmarshal(raw, &v) }
+    expected, err := version(c, v)
+    if err != nil { return writeErr(c, err) }
+
+    row, err := h.Repo.Update(c.Request().Context(), resources[name], c.Param("id"), expected, body, actor(c))
+    if err != nil { return writeErr(c, err) }
+    return c.JSON(http.StatusOK, map[string]any{"item": row})
+  }
+}
+
+// Delete — DELETE /admin/:resource/:id?updated_at=
+func (h Handler) Delete(name string) echo.HandlerFunc {
+  return func(c echo.Context) error {
+    expected, err := version(c, "")
+    if err != nil { return writeErr(c, err) }
+    if err := h.Repo.Delete(c.Request().Context(), resources[name], c.Param("id"), expected, actor(c)); err != nil {
+      return writeErr(c, err)
+    }
+    return c.NoContent(http.StatusNoContent)
+  }
+}
+
+func decodeBody(c echo.Context) (map[string]json.RawMessage, error) {
+  var body map[string]json.RawMessage
+  if err := json.NewDecoder(c.Request().Body).Decode(&body); err != nil { return nil, err }
+  return body, nil
+}
+
+// version — body-дегі updated_at, әйтпесе ?updated_at=.
+func version(c echo.Context, fromBody string) (time.Time, error) {
+  v := fromBody
+  if v == "" { v = c.QueryParam("updated_at") }
+  if v == "" { return time.Time{}, ErrVersionRequired }
+  t, err := time.Parse(time.RFC3339Nano, v)
+  if err != nil { return time.Time{}, &ValidationError{Field: "updated_at", Msg: "must be RFC 3339 time"} }
+  return t, nil
+}
+
+func actor(c echo.Context) Actor {
+  u, _ := middleware.UserFrom(c)
+  return Actor{ID: u.ID, IP: c.RealIP()}
+}
+
+func writeErr(c echo.Context, err error) error {
+  var ve *ValidationError
+  var ce *ConflictError
+  var de *DBError
+  switch {
+  case errors.As(err, &ve):
+    return c.JSON(http.StatusBadRequest, map[string]string{"error": ve.Msg, "field": ve.Field})
+  case errors.As(err, &ce):
+    return c.JSON(http.StatusConflict, map[string]any{"error": ce.Error(), "current": ce.Current})
+  case errors.As(err, &de):
+    return c.JSON(de.Status, map[string]string{"error": de.Msg})
+  case errors.Is(err, ErrNotFound):
+    return c.JSON(http.StatusNotFound, map[string]string{"error": err.Error()})
+  case errors.Is(err, ErrNoFields):
+    return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+  case errors.Is(err, ErrVersionRequired):
+    return c.JSON(http.StatusPreconditionRequired, map[string]string{"error": err.Error()})
+  }
+  return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+}
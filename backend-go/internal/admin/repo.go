@@ -0,0 +1,261 @@
+package admin
+
+import (
+  "context"
+  "encoding/json"
+  "errors"
+  "fmt"
+  "net/http"
+  "strings"
+  "time"
+
+  "github.com/jackc/pgx/v5"
+  "github.com/jackc/pgx/v5/pgconn"
+  "github.com/jackc/pgx/v5/pgxpool"
+
+  "unichance-backend-go/internal/audit"
+)
+
+var (
+  ErrNotFound = errors.New("not found")
+  ErrNoFields = errors.New("no fields to update")
+  ErrVersionRequired = errors.New("updated_at required")
+)
+
+// ConflictError — updated_at сәйкес келмеді (жазбаны басқа біреу өзгерткен).
+// Current — жазбаның қазіргі күйі, клиент қайта біріктіре алады.
+type ConflictError struct {
+  Current json.RawMessage
+}
+
+func (e *ConflictError) Error() string { return "record was modified by someone else" }
+
+// DBError — unique/FK/check бұзылуы; Status 400 немесе 409.
+type DBError struct {
+  Status int
+  Msg string
+}
+
+func (e *DBError) Error() string { return e.Msg }
+
+type Repo struct {
+  DB *pgxpool.Pool
+}
+
+// Actor — өзгерісті жасаған admin (audit үшін).
+type Actor struct {
+  ID string
+  IP string
+}
+
+func (res resource) rowSQL() string {
+  s := "to_jsonb(" + res.name + ")"
+  for _, h := range res.hidden { s += " - '" + h + "'" }
+  return s
+}
+
+func (r Repo) Get(ctx context.Context, res resource, id string) (json.RawMessage, error) {
+  var row json.RawMessage
+  err := r.DB.QueryRow(ctx,
+    `SELECT `+res.rowSQL()+` FROM `+res.name+` WHERE `+res.key+` = $1`, id,
+  ).Scan(&row)
+  if errors.Is(err, pgx.ErrNoRows) { return nil, ErrNotFound }
+  return row, mapErr(err)
+}
+
+// List — updated_at бойынша соңғы өзгергендер алдымен.
+func (r Repo) List(ctx context.Context, res resource, filters map[string]string, limit, offset int) ([]json.RawMessage, error) {
+  where := []string{"true"}
+  args := []any{}
+  for _, f := range res.filters {
+    v, ok := filters[f]
+    if !ok || v == "" { continue }
+    args = append(args, v)
+    where = append(where, fmt.Sprintf("%s = $%d", f, len(args)))
+  }
+  args = append(args, limit, offset)
+
+  rows, err := r.DB.Query(ctx, fmt.Sprintf(`
+    SELECT %s FROM %s WHERE %s
+    ORDER BY updated_at DESC, %s ASC
+    LIMIT $%d OFFSET $%d
+  `, res.rowSQL(), res.name, strings.Join(where, " AND "), res.key, len(args)-1, len(args)), args...)
+  if err != nil { return nil, mapErr(err) }
+  defer rows.Close()
+
+  out := []json.RawMessage{}
+  for rows.Next() {
+    var row json.RawMessage
+    if err := rows.Scan(&row); err != nil { return nil, err }
+    out = append(out, row)
+  }
+  return out, mapErr(rows.Err())
+}
+
+func (r Repo) Create(ctx context.Context, res resource, body map[string]json.RawMessage, actor Actor) (json.RawMessage, error) {
+  cols, vals, err := res.values(body, true)
+  if err != nil { return nil, err }
+
+  ph := make([]string, len(cols))
+  for i := range cols { ph[i] = fmt.Sprintf("$%d", i+1) }
+
+  tx, err := r.DB.Begin(ctx)
+  if err != nil { return nil, err }
+  defer tx.Rollback(ctx)
+
+  var id string
+  var row json.RawMessage
+  err = tx.QueryRow(ctx, `
+    INSERT INTO `+res.name+`(`+strings.Join(cols, ",")+`) VALUES (`+strings.Join(ph, ",")+`)
+    RETURNING `+res.key+`::text, `+res.rowSQL(), vals...,
+  ).Scan(&id, &row)
+  if err != nil { return nil, mapErr(err) }
+
+  if err := res.validateRow(row); err != nil { return nil, err }
+  if err := audit.Record(ctx, tx, audit.Event{
+    ActorID: actor.ID, Action: audit.Create, Entity: res.name, EntityID: id, After: row, IP: actor.IP,
+  }); err != nil {
+    return nil, err
+  }
+  return row, tx.Commit(ctx)
+}
+
+// Update applies a partial update when expected equals the row's updated_at.
+func (r Repo) Update(ctx context.Context, res resource, id string, expected time.Time, body map[string]json.RawMessage, actor Actor) (json.RawMessage, error) {
+  cols, vals, err := res.values(body, false)
+  if err != nil { return nil, err }
+  if len(cols) == 0 { return nil, ErrNoFields }
+
+  tx, err := r.DB.Begin(ctx)
+  if err != nil { return nil, err }
+  defer tx.Rollback(ctx)
+
+  before, err := lockVersion(ctx, tx, res, id, expected)
+  if err != nil { return nil, err }
+
+  set := make([]string, len(cols))
+  for i, c := range cols { set[i] = fmt.Sprintf("%s = $%d", c, i+2) }
+  var after json.RawMessage
+  err = tx.QueryRow(ctx, `
+    UPDATE `+res.name+` SET `+strings.Join(set, ", ")+`
+    WHERE `+res.key+` = $1
+    RETURNING `+res.rowSQL(), append([]any{id}, vals...)...,
+  ).Scan(&after)
+  if err != nil { return nil, mapErr(err) }
+
+  if err := res.validateRow(after); err != nil { return nil, err }
+  if res.reindexSQL != "" && touches(cols, res.reindex) {
+    if _, err := tx.Exec(ctx, res.reindexSQL, id); err != nil { return nil, err }
+  }
+  if err := audit.Record(ctx, tx, audit.Event{
+    ActorID: actor.ID, Action: audit.Update, Entity: res.name, EntityID: id,
+    Before: before, After: after, Meta: map[string]any{"fields": cols}, IP: actor.IP,
+  }); err != nil {
+    return nil, err
+  }
+  return after, tx.Commit(ctx)
+}
+
+// Delete removes the row when expected equals its updated_at. FK cascades
+// (university → programs → requirements) follow the schema.
+func (r Repo) Delete(ctx context.Context, res resource, id string, expected time.Time, actor Actor) error {
+  tx, err := r.DB.Begin(ctx)
+  if err != nil { return err }
+  defer tx.Rollback(ctx)
+
+  before, err := lockVersion(ctx, tx, res, id, expected)
+  if err != nil { return err }
+  if _, err := tx.Exec(ctx, `DELETE FROM `+res.name+` WHERE `+res.key+` = $1`, id); err != nil {
+    return mapErr(err)
+  }
+  if err := audit.Record(ctx, tx, audit.Event{
+    ActorID: actor.ID, Action: audit.Delete, Entity: res.name, EntityID: id, Before: before, IP: actor.IP,
+  }); err != nil {
+    return err
+  }
+  return tx.Commit(ctx)
+}
+
+// lockVersion locks the row and checks optimistic concurrency on updated_at.
+func lockVersion(ctx context.Context, tx pgx.Tx, res resource, id string, expected time.Time) (json.RawMessage, error) {
+  var row json.RawMessage
+  var same bool
+  err := tx.QueryRow(ctx, `
+    SELECT `+res.rowSQL()+`, updated_at = $2 FROM `+res.name+`
+    WHERE `+res.key+` = $1
+    FOR UPDATE
+  `, id, expected).Scan(&row, &same)
+  if errors.Is(err, pgx.ErrNoRows) { return nil, ErrNotFound }
+  if err != nil { return nil, mapErr(err) }
+  if !same { return nil, &ConflictError{Current: row} }
+  return row, nil
+}
+
+// values parses the body against the resource columns. Unknown fields are
+// rejected; server-maintained fields (key, created_at, updated_at, hidden) are
+// ignored so a client may send back the row it read.
+func (res resource) values(body map[string]json.RawMessage, create bool) ([]string, []any, error) {
+  for name := range body {
+    if res.readOnly(name) || (!create && name == res.key) { continue }
+    c, ok := res.column(name)
+    if !ok { return nil, nil, &ValidationError{Field: name, Msg: "unknown field"} }
+    if c.immutable && !create { return nil, nil, &ValidationError{Field: name, Msg: "cannot be changed"} }
+  }
+
+  var cols []string
+  var vals []any
+  for _, c := range res.cols {
+    raw, ok := body[c.name]
+    if !ok {
+      if create && c.required { return nil, nil, &ValidationError{Field: c.name, Msg: "required"} }
+      continue
+    }
+    if c.immutable && !create { continue }
+    v, err := c.parse(raw)
+    if err != nil { return nil, nil, err }
+    cols = append(cols, c.name)
+    vals = append(vals, v)
+  }
+  return cols, vals, nil
+}
+
+func (res resource) readOnly(name string) bool {
+  switch name {
+  case "created_at", "updated_at":
+    return true
+  case res.key:
+    return !res.keyInBody
+  }
+  return contains(res.hidden, name)
+}
+
+func (res resource) validateRow(row json.RawMessage) error {
+  if res.check == nil { return nil }
+  var m map[string]any
+  if err := json.Unmarshal(row, &m); err != nil { return err }
+  return res.check(m)
+}
+
+func touches(cols, watched []string) bool {
+  for _, c := range cols {
+    if contains(watched, c) { return true }
+  }
+  return false
+}
+
+// mapErr turns constraint violations into client errors.
+func mapErr(err error) error {
+  var pgErr *pgconn.PgError
+  if !errors.As(err, &pgErr) { return err }
+  switch pgErr.Code {
+  case "23505":
+    return &DBError{Status: http.StatusConflict, Msg: "already exists: " + pgErr.ConstraintName}
+  case "23503":
+    return &DBError{Status: http.StatusBadRequest, Msg: "referenced record not found: " + pgErr.ConstraintName}
+  case "23502":
+    return &DBError{Status: http.StatusBadRequest, Msg: pgErr.ColumnName + ": must not be null"}
+  case "23514", "22P02", "22003", "22007", "22008":
+    return &DBError{Status: http.StatusBadRequest, Msg: pgErr.Message}
+  }
+  return err
+}
@@ -0,0 +1,138 @@
+package admin
+
+import (
+  "encoding/json"
+  "errors"
+  "net/http"
+  "reflect"
+  "testing"
+
+  "github.com/jackc/pgx/v5/pgconn"
+)
+
+func body(s string) map[string]json.RawMessage {
+  var m map[string]json.RawMessage
+  if err := json.Unmarshal([]byte(s), &m); err != nil { panic(err) }
+  return m
+}
+
+func TestResourceValues(t *testing.T) {
+  const pid = "0b6f6c39-5a4e-4b59-8f1e-1a2b3c4d5e6f"
+  tests := []struct {
+    name string
+    res string
+    body string
+    create bool
+    cols []string
+    field string // күтілетін ValidationError.Field; бос болса қате жоқ
+    msg string
+  }{
+    {
+      name: "create keeps column order",
+      res: "universities", create: true,
+      body: `{"country_code":"kz","name":"KBTU"}`,
+      cols: []string{"name", "country_code"},
+    },
+    {
+      name: "create without required",
+      res: "universities", create: true,
+      body: `{"name":"KBTU"}`,
+      field: "country_code", msg: "required",
+    },
+    {
+      name: "update skips missing required",
+      res: "universities",
+      body: `{"city":"Almaty"}`,
+      cols: []string{"city"},
+    },
+    {
+      name: "unknown field",
+      res: "universities",
+      body: `{"city":"Almaty","mascot":"owl"}`,
+      field: "mascot", msg: "unknown field",
+    },
+    {
+      name: "read-only fields are ignored",
+      res: "programs",
+      body: `{"id":"x","created_at":"x","updated_at":"x","search_vector":"x","search_text":"x","title":"CS"}`,
+      cols: []string{"title"},
+    },
+    {
+      name: "server key ignored on create",
+      res: "universities", create: true,
+      body: `{"id":"x","name":"KBTU","country_code":"KZ"}`,
+      cols: []string{"name", "country_code"},
+    },
+    {
+      name: "key in body on create",
+      res: "requirements", create: true,
+      body: `{"program_id":"` + pid + `","min_ielts":6.5}`,
+      cols: []string{"program_id", "min_ielts"},
+    },
+    {
+      name: "key in body ignored on update",
+      res: "requirements",
+      body: `{"program_id":"` + pid + `","min_ielts":6.5}`,
+      cols: []string{"min_ielts"},
+    },
+    {
+      name: "invalid value",
+      res: "requirements",
+      body: `{"min_ielts":10}`,
+      field: "min_ielts", msg: "must be <= 9",
+    },
+  }
+  for _, tt := range tests {
+    cols, vals, err := resources[tt.res].values(body(tt.body), tt.create)
+    if tt.field != "" {
+      ve, ok := err.(*ValidationError)
+      if !ok || ve.Field != tt.field || ve.Msg != tt.msg { t.Errorf("%s: err = %v, want %s: %s", tt.name, err, tt.field, tt.msg) }
+      continue
+    }
+    if err != nil { t.Errorf("%s: %v", tt.name, err); continue }
+    if !reflect.DeepEqual(cols, tt.cols) || len(vals) != len(cols) {
+      t.Errorf("%s: cols = %v (%d values), want %v", tt.name, cols, len(vals), tt.cols)
+    }
+  }
+}
+
+// immutable бағанды create жазады, update өзгерте алмайды.
+func TestResourceValuesImmutable(t *testing.T) {
+  res := resource{name: "t", key: "id", cols: []column{{name: "owner", kind: kText, immutable: true}}}
+  if cols, _, err := res.values(body(`{"owner":"a"}`), true); err != nil || len(cols) != 1 {
+    t.Errorf("create: cols = %v, err = %v", cols, err)
+  }
+  _, _, err := res.values(body(`{"owner":"b"}`), false)
+  if ve, ok := err.(*ValidationError); !ok || ve.Field != "owner" || ve.Msg != "cannot be changed" {
+    t.Errorf("update: err = %v, want owner: cannot be changed", err)
+  }
+}
+
+func TestMapErr(t *testing.T) {
+  plain := errors.New("boom")
+  tests := []struct {
+    err error
+    status int // 0 — қате өзгеріссіз қайтады
+    msg string
+  }{
+    {&pgconn.PgError{Code: "23505", ConstraintName: "sources_code_key"}, http.StatusConflict, "already exists: sources_code_key"},
+    {&pgconn.PgError{Code: "23503", ConstraintName: "programs_university_id_fkey"}, http.StatusBadRequest, "referenced record not found: programs_university_id_fkey"},
+    {&pgconn.PgError{Code: "23502", ColumnName: "title"}, http.StatusBadRequest, "title: must not be null"},
+    {&pgconn.PgError{Code: "23514", Message: "violates check"}, http.StatusBadRequest, "violates check"},
+    {&pgconn.PgError{Code: "22P02", Message: "invalid input syntax"}, http.StatusBadRequest, "invalid input syntax"},
+    {&pgconn.PgError{Code: "40001"}, 0, ""},
+    {plain, 0, ""},
+    {nil, 0, ""},
+  }
+  for _, tt := range tests {
+    got := mapErr(tt.err)
+    if tt.status == 0 {
+      if got != tt.err { t.Errorf("mapErr(%v) = %v, want unchanged", tt.err, got) }
+      continue
+    }
+    var dbErr *DBError
+    if !errors.As(got, &dbErr) || dbErr.Status != tt.status || dbErr.Msg != tt.msg {
+      t.Errorf("mapErr(%v) = %v, want %d %q", tt.err, got, tt.status, tt.msg)
+    }
+  }
+}
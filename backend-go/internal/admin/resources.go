@@ -0,0 +1,268 @@
+package admin
+
+import (
+  "encoding/json"
+  "fmt"
+  "net/url"
+  "regexp"
+  "strings"
+  "time"
+
+  "unichance-backend-go/internal/currency"
+  "unichance-backend-go/internal/grading"
+)
+
+type kind int
+
+const (
+  kText kind = iota
+  kInt
+  kFloat
+  kBool
+  kTime
+  kUUID
+)
+
+// column — admin арқылы жазылатын баған және оның валидациясы.
+type column struct {
+  name string
+  kind kind
+  required bool // create кезінде міндетті, null болмайды
+  immutable bool // тек create (мысалы requirements.program_id)
+  min, max *float64 // kInt/kFloat шектері
+  oneOf []string // kText рұқсат етілген мәндері
+  norm func(string) string
+  check func(string) error
+}
+
+// resource — бір кесте: URL сегменті = audit entity = кесте аты.
+type resource struct {
+  name string
+  key string // PK бағаны
+  keyInBody bool // PK клиенттен келеді (requirements.program_id)
+  cols []column
+  hidden []string // жауаптан алынатын бағандар
+  filters []string // GET тізімінде ?col= сүзгілері
+  check func(row map[string]any) error // жазылған жолды транзакция ішінде тексеру
+  reindex []string // осы бағандар өзгерсе reindexSQL орындалады ($1 = key)
+  reindexSQL string
+}
+
+func rng(min, max float64) (*float64, *float64) { return &min, &max }
+
+func num(name string, k kind, min, max float64) column {
+  c := column{name: name, kind: k}
+  c.min, c.max = rng(min, max)
+  return c
+}
+
+var uuidRe = regexp.MustCompile(`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`)
+var countryRe = regexp.MustCompile(`^[A-Z]{2}$`)
+
+func httpURL(s string) error {
+  u, err := url.Parse(s)
+  if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+    return fmt.Errorf("must be an http(s) URL")
+  }
+  return nil
+}
+
+func countryCode(s string) error {
+  if !countryRe.MatchString(s) { return fmt.Errorf("must be ISO 3166-1 alpha-2") }
+  return nil
+}
+
+func currencyCode(s string) error {
+  if len(s) != 3 { return fmt.Errorf("must be ISO 4217 code") }
+  return nil // белгісіз код FK (currencies) арқылы 400 береді
+}
+
+func gradingSystem(s string) error {
+  if !grading.Known(s) { return fmt.Errorf("unknown grading system") }
+  return nil
+}
+
+var (
+  upper = strings.ToUpper
+  lower = strings.ToLower
+)
+
+var linkTypes = []string{"website", "admissions", "scholarships", "financial_aid", "apply", "contact"}
+
+// Resources — admin CRUD қолжетімді кестелер.
+var Resources = []string{"universities", "programs", "requirements", "university_links", "sources"}
+
+var resources = map[string]resource{
+  "universities": {
+    name: "universities", key: "id",
+    cols: []column{
+      {name: "name", kind: kText, required: true},
+      {name: "country_code", kind: kText, required: true, norm: upper, check: countryCode},
+      {name: "city", kind: kText},
+      {name: "website", kind: kText, check: httpURL},
+      num("qs_rank", kInt, 1, 5000),
+      num("the_rank", kInt, 1, 5000),
+      {name: "data_updated_at", kind: kTime},
+    },
+    filters: []string{"country_code"},
+    // programs.search_vector/search_text университет атауы мен орнын қамтиды
+    reindex: []string{"name", "country_code", "city"},
+    reindexSQL: `UPDATE programs SET title = title WHERE university_id = $1`,
+  },
+  "programs": {
+    name: "programs", key: "id",
+    cols: []column{
+      {name: "university_id", kind: kUUID, required: true},
+      {name: "title", kind: kText, required: true},
+      {name: "degree_level", kind: kText, required: true, norm: lower, oneOf: []string{"bachelor", "master"}},
+      {name: "field", kind: kText, required: true},
+      {name: "language", kind: kText, required: true},
+      num("tuition_amount", kFloat, 0, 1e7),
+      {name: "tuition_currency", kind: kText, norm: currency.Normalize, check: currencyCode},
+      {name: "has_scholarship", kind: kBool},
+      {name: "scholarship_type", kind: kText},
+      num("scholarship_percent_min", kInt, 0, 100),
+      num("scholarship_percent_max", kInt, 0, 100),
+      {name: "description", kind: kText},
+      {name: "data_source", kind: kText},
+      {name: "data_updated_at", kind: kTime},
+    },
+    hidden: []string{"search_vector", "search_text"},
+    filters: []string{"university_id", "degree_level"},
+    check: func(row map[string]any) error {
+      lo, hi := row["scholarship_percent_min"], row["scholarship_percent_max"]
+      if l, ok := lo.(float64); ok {
+        if h, ok := hi.(float64); ok && l > h {
+          return &ValidationError{Field: "scholarship_percent_min", Msg: "must not exceed scholarship_percent_max"}
+        }
+      }
+      if row["tuition_amount"] != nil && row["tuition_currency"] == nil {
+        return &ValidationError{Field: "tuition_currency", Msg: "required when tuition_amount is set"}
+      }
+      return nil
+    },
+  },
+  "requirements": {
+    name: "requirements", key: "program_id", keyInBody: true,
+    cols: []column{
+      {name: "program_id", kind: kUUID, required: true, immutable: true},
+      num("min_gpa", kFloat, 0, 100),
+      {name: "gpa_system", kind: kText, norm: lower, check: gradingSystem},
+      num("min_ielts", kFloat, 0, 9),
+      num("min_toefl", kInt, 0, 120),
+      num("min_duolingo", kInt, 10, 160),
+      num("min_pte", kInt, 10, 90),
+      num("min_cambridge", kInt, 80, 230),
+      num("min_sat", kInt, 400, 1600),
+      num("min_act", kInt, 1, 36),
+      num("min_gre", kInt, 260, 340),
+      num("min_gmat", kInt, 200, 800),
+      num("min_unt", kInt, 0, 140),
+      {name: "notes", kind: kText},
+    },
+  },
+  "university_links": {
+    name: "university_links", key: "id",
+    cols: []column{
+      {name: "university_id", kind: kUUID, required: true},
+      {name: "source_id", kind: kUUID},
+      {name: "link_type", kind: kText, required: true, norm: lower, oneOf: linkTypes},
+      {name: "url", kind: kText, required: true, check: httpURL},
+      {name: "title", kind: kText},
+      {name: "is_official", kind: kBool},
+      num("priority", kInt, 1, 100),
+      {name: "last_verified_at", kind: kTime},
+    },
+    filters: []string{"university_id", "source_id", "link_type"},
+  },
+  "sources": {
+    name: "sources", key: "id",
+    cols: []column{
+      {name: "code", kind: kText, required: true, norm: lower},
+      {name: "name", kind: kText, required: true},
+      {name: "kind", kind: kText, required: true, norm: lower, oneOf: []string{"api", "dataset", "scrape"}},
+      {name: "base_url", kind: kText, check: httpURL},
+      {name: "docs_url", kind: kText, check: httpURL},
+      {name: "license", kind: kText},
+      num("reliability", kInt, 1, 5),
+      {name: "is_active", kind: kBool},
+      num("refresh_interval_hours", kInt, 1, 24*365),
+      {name: "last_fetched_at", kind: kTime},
+    },
+    filters: []string{"kind"},
+  },
+}
+
+func (r resource) column(name string) (column, bool) {
+  for _, c := range r.cols {
+    if c.name == name { return c, true }
+  }
+  return column{}, false
+}
+
+// ValidationError — 400 жауабы, Field клиентке көрсетіледі.
+type ValidationError struct {
+  Field string
+  Msg string
+}
+
+func (e *ValidationError) Error() string { return e.Field + ": " + e.Msg }
+
+// parse decodes one JSON value for the column; nil means SQL NULL.
+func (c column) parse(raw json.RawMessage) (any, error) {
+  bad := func(msg string) error { return &ValidationError{Field: c.name, Msg: msg} }
+  if len(raw) == 0 || string(raw) == "null" {
+    if c.required { return nil, bad("required") }
+    return nil, nil
+  }
+
+  switch c.kind {
+  case kText, kUUID, kTime:
+    var s string
+    if err := json.Unmarshal(raw, &s); err != nil { return nil, bad("must be a string") }
+    s = strings.TrimSpace(s)
+    if s == "" {
+      if c.required { return nil, bad("required") }
+      return nil, nil
+    }
+    switch c.kind {
+    case kUUID:
+      if !uuidRe.MatchString(s) { return nil, bad("must be a UUID") }
+      return strings.ToLower(s), nil
+    case kTime:
+      if t, err := time.Parse(time.RFC3339Nano, s); err == nil { return t, nil }
+      if t, err := time.Parse("2006-01-02", s); err == nil { return t, nil }
+      return nil, bad("must be RFC 3339 time or YYYY-MM-DD")
+    }
+    if c.norm != nil { s = c.norm(s) }
+    if len(c.oneOf) > 0 && !contains(c.oneOf, s) {
+      return nil, bad("must be one of " + strings.Join(c.oneOf, ", "))
+    }
+    if c.check != nil {
+      if err := c.check(s); err != nil { return nil, bad(err.Error()) }
+    }
+    return s, nil
+
+  case kInt, kFloat:
+    var f float64
+    if err := json.Unmarshal(raw, &f); err != nil { return nil, bad("must be a number") }
+    if c.kind == kInt && f != float64(int64(f)) { return nil, bad("must be an integer") }
+    if c.min != nil && f < *c.min { return nil, bad(fmt.Sprintf("must be >= %g", *c.min)) }
+    if c.max != nil && f > *c.max { return nil, bad(fmt.Sprintf("must be <= %g", *c.max)) }
+    if c.kind == kInt { return int64(f), nil }
+    return f, nil
+
+  case kBool:
+    var b bool
+    if err := json.Unmarshal(raw, &b); err != nil { return nil, bad("must be a boolean") }
+    return b, nil
+  }
+  return nil, bad("unsupported")
+}
+
+func contains(list []string, s string) bool {
+  for _, v := range list {
+    if v == s { return true }
+  }
+  return false
+}
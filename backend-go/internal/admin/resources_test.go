@@ -0,0 +1,70 @@
+package admin
+
+import (
+  "encoding/json"
+  "reflect"
+  "testing"
+  "time"
+)
+
+func colOf(res, name string) column {
+  c, ok := resources[res].column(name)
+  if !ok { panic("no column " + res + "." + name) }
+  return c
+}
+
+func TestColumnParse(t *testing.T) {
+  date := time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)
+  stamp := time.Date(2025, 3, 1, 12, 30, 0, 0, time.UTC)
+
+  tests := []struct {
+    name string
+    col column
+    raw string // "" — өріс бос (json.RawMessage nil)
+    want any
+    err string // күтілетін ValidationError.Msg; бос болса қате жоқ
+  }{
+    {"int in range", colOf("universities", "qs_rank"), `42`, int64(42), ""},
+    {"int with zero fraction", colOf("universities", "qs_rank"), `42.0`, int64(42), ""},
+    {"int fraction", colOf("universities", "qs_rank"), `4.5`, nil, "must be an integer"},
+    {"int below min", colOf("universities", "qs_rank"), `0`, nil, "must be >= 1"},
+    {"int above max", colOf("universities", "qs_rank"), `5001`, nil, "must be <= 5000"},
+    {"int from string", colOf("universities", "qs_rank"), `"42"`, nil, "must be a number"},
+    {"float", colOf("requirements", "min_ielts"), `6.5`, 6.5, ""},
+    {"float above max", colOf("requirements", "min_ielts"), `9.5`, nil, "must be <= 9"},
+    {"oneOf normalized", colOf("programs", "degree_level"), `" Master "`, "master", ""},
+    {"oneOf rejects", colOf("programs", "degree_level"), `"phd"`, nil, "must be one of bachelor, master"},
+    {"check after norm", colOf("universities", "country_code"), `"kz"`, "KZ", ""},
+    {"check rejects", colOf("universities", "country_code"), `"KAZ"`, nil, "must be ISO 3166-1 alpha-2"},
+    {"url", colOf("universities", "website"), `"ftp://example.com"`, nil, "must be an http(s) URL"},
+    {"grading system", colOf("requirements", "gpa_system"), `"DE_5"`, "de_5", ""},
+    {"unknown grading system", colOf("requirements", "gpa_system"), `"xx_9"`, nil, "unknown grading system"},
+    {"uuid lowercased", colOf("programs", "university_id"), `"0B6F6C39-5A4E-4B59-8F1E-1A2B3C4D5E6F"`, "0b6f6c39-5a4e-4b59-8f1e-1a2b3c4d5e6f", ""},
+    {"bad uuid", colOf("programs", "university_id"), `"42"`, nil, "must be a UUID"},
+    {"time rfc3339", colOf("universities", "data_updated_at"), `"2025-03-01T12:30:00Z"`, stamp, ""},
+    {"time date only", colOf("universities", "data_updated_at"), `"2025-03-01"`, date, ""},
+    {"bad time", colOf("universities", "data_updated_at"), `"01.03.2025"`, nil, "must be RFC 3339 time or YYYY-MM-DD"},
+    {"bool", colOf("programs", "has_scholarship"), `true`, true, ""},
+    {"bool from string", colOf("programs", "has_scholarship"), `"yes"`, nil, "must be a boolean"},
+    {"text from number", colOf("universities", "city"), `7`, nil, "must be a string"},
+    {"optional null", colOf("universities", "city"), `null`, nil, ""},
+    {"optional empty string", colOf("universities", "city"), `"  "`, nil, ""},
+    {"optional missing", colOf("universities", "city"), "", nil, ""},
+    {"required null", colOf("universities", "name"), `null`, nil, "required"},
+    {"required empty string", colOf("universities", "name"), `""`, nil, "required"},
+  }
+  for _, tt := range tests {
+    var raw json.RawMessage
+    if tt.raw != "" { raw = json.RawMessage(tt.raw) }
+    got, err := tt.col.parse(raw)
+    if tt.err != "" {
+      ve, ok := err.(*ValidationError)
+      if !ok || ve.Msg != tt.err || ve.Field != tt.col.name {
+        t.Errorf("%s: err = %v, want %s: %s", tt.name, err, tt.col.name, tt.err)
+      }
+      continue
+    }
+    if err != nil { t.Errorf("%s: %v", tt.name, err); continue }
+    if !reflect.DeepEqual(got, tt.want) { t.Errorf("%s: parse(%s) = %#v, want %#v", tt.name, tt.raw, got, tt.want) }
+  }
+}
@@ -0,0 +1,41 @@
+package admissions
+
+import "unichance-backend-go/internal/scoring"
+
+type YearStats struct {
+	Year           int      `json:"year"`
+	Applicants     *int     `json:"applicants,omitempty"`
+	Admitted       *int     `json:"admitted,omitempty"`
+	Enrolled       *int     `json:"enrolled,omitempty"`
+	AcceptanceRate *float64 `json:"acceptance_rate,omitempty"`
+	AvgGPA         *float64 `json:"avg_gpa,omitempty"`
+	AvgIELTS       *float64 `json:"avg_ielts,omitempty"`
+	AvgTOEFL       *int     `json:"avg_toefl,omitempty"`
+	AvgSAT         *int     `json:"avg_sat,omitempty"`
+	SourceCode     *string  `json:"source_code,omitempty"`
+}
+
+// Summary — соңғы бірнеше жылдың орташа мәндері ("typical admitted student").
+type Summary struct {
+	FromYear       int      `json:"from_year"`
+	ToYear         int      `json:"to_year"`
+	Years          int      `json:"years"`
+	Applicants     *int     `json:"applicants,omitempty"`
+	AcceptanceRate *float64 `json:"acceptance_rate,omitempty"`
+	AvgGPA         *float64 `json:"avg_gpa,omitempty"`
+	AvgIELTS       *float64 `json:"avg_ielts,omitempty"`
+	AvgTOEFL       *int     `json:"avg_toefl,omitempty"`
+	AvgSAT         *int     `json:"avg_sat,omitempty"`
+}
+
+func (s *Summary) ScoringStats() *scoring.Stats {
+	if s == nil {
+		return nil
+	}
+	return &scoring.Stats{
+		AcceptanceRate: s.AcceptanceRate,
+		AvgGPA:         s.AvgGPA,
+		AvgIELTS:       s.AvgIELTS,
+		AvgSAT:         s.AvgSAT,
+	}
+}
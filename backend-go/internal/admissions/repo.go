@@ -0,0 +1,101 @@
+package admissions
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// SummaryYears — Summary қанша соңғы жылды қамтиды (TS prediction.ts-тегідей 3 жыл).
+const SummaryYears = 3
+
+type Repo struct {
+	DB *pgxpool.Pool
+}
+
+func (r Repo) ListByProgram(ctx context.Context, programID string) ([]YearStats, error) {
+	rows, err := r.DB.Query(ctx, `
+    SELECT
+      a.year, a.applicants, a.admitted, a.enrolled,
+      COALESCE(a.acceptance_rate, ROUND(a.admitted * 100.0 / NULLIF(a.applicants, 0), 2))::float8,
+      a.avg_gpa::float8, a.avg_ielts::float8, a.avg_toefl, a.avg_sat,
+      s.code
+    FROM admission_stats a
+    LEFT JOIN sources s ON s.id = a.source_id
+    WHERE a.program_id = $1
+    ORDER BY a.year DESC
+  `, programID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	out := []YearStats{}
+	for rows.Next() {
+		var y YearStats
+		if err := rows.Scan(
+			&y.Year, &y.Applicants, &y.Admitted, &y.Enrolled,
+			&y.AcceptanceRate,
+			&y.AvgGPA, &y.AvgIELTS, &y.AvgTOEFL, &y.AvgSAT,
+			&y.SourceCode,
+		); err != nil {
+			return nil, err
+		}
+		out = append(out, y)
+	}
+	return out, rows.Err()
+}
+
+// Summary averages the latest SummaryYears years that have data.
+// Returns nil when the program has no statistics at all.
+func (r Repo) Summary(ctx context.Context, programID string) (*Summary, error) {
+	m, err := r.SummaryMany(ctx, []string{programID})
+	if err != nil {
+		return nil, err
+	}
+	return m[programID], nil
+}
+
+func (r Repo) SummaryMany(ctx context.Context, programIDs []string) (map[string]*Summary, error) {
+	rows, err := r.DB.Query(ctx, `
+    WITH ranked AS (
+      SELECT a.*,
+        COALESCE(a.acceptance_rate, ROUND(a.admitted * 100.0 / NULLIF(a.applicants, 0), 2)) AS rate,
+        row_number() OVER (PARTITION BY a.program_id ORDER BY a.year DESC) AS rn
+      FROM admission_stats a
+      WHERE a.program_id = ANY($1::uuid[])
+    )
+    SELECT
+      program_id::text,
+      MIN(year), MAX(year), COUNT(*)::int,
+      ROUND(AVG(applicants))::int,
+      ROUND(AVG(rate), 2)::float8,
+      ROUND(AVG(avg_gpa), 2)::float8,
+      ROUND(AVG(avg_ielts), 1)::float8,
+      ROUND(AVG(avg_toefl))::int,
+      ROUND(AVG(avg_sat))::int
+    FROM ranked
+    WHERE rn <= $2
+    GROUP BY program_id
+  `, programIDs, SummaryYears)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	out := map[string]*Summary{}
+	for rows.Next() {
+		var id string
+		var s Summary
+		if err := rows.Scan(
+			&id,
+			&s.FromYear, &s.ToYear, &s.Years,
+			&s.Applicants,
+			&s.AcceptanceRate, &s.AvgGPA, &s.AvgIELTS, &s.AvgTOEFL, &s.AvgSAT,
+		); err != nil {
+			return nil, err
+		}
+		out[id] = &s
+	}
+	return out, rows.Err()
+}
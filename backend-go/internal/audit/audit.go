@@ -0,0 +1,55 @@
+package audit
+
+import (
+  "context"
+  "encoding/json"
+
+  "github.com/jackc/pgx/v5/pgconn"
+)
+
+// Actions.
+const (
+  Create = "create"
+  Update = "update"
+  Delete = "delete"
+
+  LoginFailed = "login_failed"
+  AccountLocked = "account_locked"
+  LoginBlocked = "login_blocked" // lockout немесе rate limit кезіндегі әрекет
+)
+
+// Event — audit_log жолы. Before/After — жазбаның JSON күйі (to_jsonb), Meta — қосымша мәлімет.
+type Event struct {
+  ActorID string // бос болса NULL
+  Action string
+  Entity string
+  EntityID string
+  Before json.RawMessage
+  After json.RawMessage
+  Meta map[string]any
+  IP string
+}
+
+// Execer — *pgxpool.Pool немесе pgx.Tx: өзгеріс пен оның audit жазбасы бір транзакцияда болуы үшін.
+type Execer interface {
+  Exec(ctx context.Context, sql string, args ...any) (pgconn.CommandTag, error)
+}
+
+func Record(ctx context.Context, db Execer, e Event) error {
+  var meta []byte
+  if len(e.Meta) > 0 {
+    b, err := json.Marshal(e.Meta)
+    if err != nil { return err }
+    meta = b
+  }
+  _, err := db.Exec(ctx, `
+    INSERT INTO audit_log(actor_id, action, entity, entity_id, before, after, meta, ip)
+    VALUES (NULLIF($1,'')::uuid, $2, NULLIF($3,''), NULLIF($4,''), $5, $6, $7, NULLIF($8,''))
+  `, e.ActorID, e.Action, e.Entity, e.EntityID, nullJSON(e.Before), nullJSON(e.After), nullJSON(meta), e.IP)
+  return err
+}
+
+func nullJSON(b []byte) any {
+  if len(b) == 0 { return nil }
+  return string(b)
+}
@@ -0,0 +1,182 @@
+package auth
+
+import (
+  "context"
+  "errors"
+  "fmt"
+  "log"
+  "net/url"
+  "strings"
+  "time"
+
+  "github.com/jackc/pgx/v5"
+  "golang.org/x/crypto/bcrypt"
+
+  "unichance-backend-go/internal/mail"
+)
+
+const (
+  purposeVerify = "verify_email"
+  purposeReset = "password_reset"
+
+  VerifyTokenTTL = 48 * time.Hour
+  ResetTokenTTL = time.Hour
+
+  mailTimeout = 30 * time.Second // фондық хат жіберу (token insert + SMTP)
+
+  MinPasswordLen = 8
+  DefaultAppURL = "http://localhost:5173"
+)
+
+var (
+  ErrInvalidEmailToken = errors.New("invalid or expired token")
+  ErrAlreadyVerified = errors.New("email already verified")
+  ErrInvalidEmail = errors.New("invalid email address")
+  ErrWeakPassword = fmt.Errorf("password must be at least %d characters", MinPasswordLen)
+)
+
+func (s Service) mailer() mail.Mailer {
+  if s.Mailer == nil { return mail.LogMailer{} }
+  return s.Mailer
+}
+
+func (s Service) link(path, token string) string {
+  base := s.AppURL
+  if base == "" { base = DefaultAppURL }
+  return strings.TrimRight(base, "/") + path + "?token=" + url.QueryEscape(token)
+}
+
+// sendVerification issues a verification token and mails the link.
+// Earlier unused verification tokens of the user stop working.
+func (s Service) sendVerification(ctx context.Context, u User) error {
+  token, err := s.issueEmailToken(ctx, u.ID, u.Email, purposeVerify, VerifyTokenTTL)
+  if err != nil { return err }
+  return s.mailer().Send(ctx, mail.Message{
+    To: u.Email,
+    Subject: "Confirm your UniChance email",
+    Body: "Open the link to confirm your email address:\n\n" + s.link("/verify-email", token) +
+      "\n\nThe link is valid for 48 hours. If you did not sign up, ignore this message.\n",
+  })
+}
+
+// ResendVerification mails a fresh verification link to the user.
+func (s Service) ResendVerification(ctx context.Context, userID string) error {
+  var u User
+  err := s.DB.QueryRow(ctx,
+    `SELECT id, email, email_verified_at IS NOT NULL FROM users WHERE id=$1`, userID,
+  ).Scan(&u.ID, &u.Email, &u.EmailVerified)
+  if err != nil { return err }
+  if u.EmailVerified { return ErrAlreadyVerified }
+  return s.sendVerification(ctx, u)
+}
+
+// VerifyEmail consumes a verification token and marks the address verified.
+// New access tokens (login or /auth/refresh) carry email_verified=true.
+func (s Service) VerifyEmail(ctx context.Context, token string) error {
+  tx, err := s.DB.Begin(ctx)
+  if err != nil { return err }
+  defer tx.Rollback(ctx)
+
+  userID, err := consumeEmailToken(ctx, tx, token, purposeVerify)
+  if err != nil { return err }
+  if _, err := tx.Exec(ctx,
+    `UPDATE users SET email_verified_at = COALESCE(email_verified_at, now()) WHERE id=$1`, userID,
+  ); err != nil {
+    return err
+  }
+  return tx.Commit(ctx)
+}
+
+// RequestPasswordReset mails a reset link when the address is registered.
+// Unknown addresses are not an error, so the endpoint does not reveal accounts.
+func (s Service) RequestPasswordReset(ctx context.Context, email string) error {
+  email = strings.TrimSpace(email)
+  var id string
+  err := s.DB.QueryRow(ctx, `SELECT id FROM users WHERE email=$1`, email).Scan(&id)
+  if errors.Is(err, pgx.ErrNoRows) { return nil }
+  if err != nil { return err }
+
+  token, err := s.issueEmailToken(ctx, id, email, purposeReset, ResetTokenTTL)
+  if err != nil { return err }
+  return s.mailer().Send(ctx, mail.Message{
+    To: email,
+    Subject: "Reset your UniChance password",
+    Body: "Open the link to choose a new password:\n\n" + s.link("/reset-password", token) +
+      "\n\nThe link is valid for 1 hour and can be used once. If you did not ask for it, ignore this message.\n",
+  })
+}
+
+// ResetPassword consumes a reset token and sets the new password. All sessions
+// are ended (token_version++, refresh tokens revoked). The mailbox is proven,
+// so the email also counts as verified.
+func (s Service) ResetPassword(ctx context.Context, token, password string) error {
+  if len(password) < MinPasswordLen { return ErrWeakPassword }
+  hash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+  if err != nil { return err }
+
+  tx, err := s.DB.Begin(ctx)
+  if err != nil { return err }
+  defer tx.Rollback(ctx)
+
+  userID, err := consumeEmailToken(ctx, tx, token, purposeReset)
+  if err != nil { return err }
+  if _, err := tx.Exec(ctx, `
+    UPDATE users SET
+      password_hash = $2,
+      token_version = token_version + 1,
+      email_verified_at = COALESCE(email_verified_at, now())
+    WHERE id=$1
+  `, userID, string(hash)); err != nil {
+    return err
+  }
+  if _, err := tx.Exec(ctx, `UPDATE refresh_tokens SET revoked_at=now() WHERE user_id=$1 AND revoked_at IS NULL`, userID); err != nil {
+    return err
+  }
+  return tx.Commit(ctx)
+}
+
+func (s Service) issueEmailToken(ctx context.Context, userID, email, purpose string, ttl time.Duration) (string, error) {
+  token, err := randomToken()
+  if err != nil { return "", err }
+
+  tx, err := s.DB.Begin(ctx)
+  if err != nil { return "", err }
+  defer tx.Rollback(ctx)
+
+  // бір мезетте тек соңғы сілтеме жарамды
+  if _, err := tx.Exec(ctx,
+    `UPDATE email_tokens SET used_at=now() WHERE user_id=$1 AND purpose=$2 AND used_at IS NULL`,
+    userID, purpose,
+  ); err != nil {
+    return "", err
+  }
+  if _, err := tx.Exec(ctx, `
+    INSERT INTO email_tokens(user_id, purpose, token_hash, email, expires_at)
+    VALUES ($1,$2,$3,$4,$5)
+  `, userID, purpose, hashToken(token), email, time.Now().Add(ttl)); err != nil {
+    return "", err
+  }
+  return token, tx.Commit(ctx)
+}
+
+// consumeEmailToken marks the token used and returns its user. The single
+// UPDATE makes it single-use under concurrency; a token sent to an address
+// the user no longer has is rejected.
+func consumeEmailToken(ctx context.Context, tx pgx.Tx, token, purpose string) (string, error) {
+  var userID string
+  err := tx.QueryRow(ctx, `
+    UPDATE email_tokens et SET used_at = now()
+    FROM users u
+    WHERE et.token_hash = $1 AND et.purpose = $2
+      AND et.used_at IS NULL AND et.expires_at > now()
+      AND u.id = et.user_id AND u.email = et.email
+    RETURNING et.user_id
+  `, hashToken(token), purpose).Scan(&userID)
+  if errors.Is(err, pgx.ErrNoRows) { return "", ErrInvalidEmailToken }
+  return userID, err
+}
+
+// logMailErr — хат жіберілмесе тіркелу/сұрау сәтсіз болмайды, тек логқа жазылады.
+func logMailErr(what string, err error) {
+  if err != nil { log.Printf("auth: %s: %v", what, err) }
+}
@@ -0,0 +1,78 @@
+package auth
+
+import (
+  "context"
+  "log"
+  "strings"
+  "time"
+
+  "github.com/labstack/echo/v4"
+
+  "unichance-backend-go/internal/audit"
+  "unichance-backend-go/internal/ratelimit"
+)
+
+// Guard — login brute-force қорғанысы: аккаунтқа token bucket және
+// қатарынан сәтсіз әрекеттерден кейін өсетін lockout. Store nil болса өшірулі.
+type Guard struct {
+  Store ratelimit.Store
+  Account ratelimit.Limit // бір email-ге login/forgot әрекеттері
+  Lockout ratelimit.Lockout
+  Audit audit.Execer // nil болса audit жазылмайды
+}
+
+func accountKey(email string) string { return strings.ToLower(strings.TrimSpace(email)) }
+
+// allow checks lockout and the per-account bucket; wait > 0 means reject.
+func (g Guard) allow(c echo.Context, scope, email string) time.Duration {
+  if g.Store == nil || email == "" { return 0 }
+  ctx := c.Request().Context()
+  key := accountKey(email)
+
+  if scope == "login" {
+    if d, err := g.Lockout.LockedFor(ctx, key); err != nil {
+      log.Printf("auth guard: %v", err)
+    } else if d > 0 {
+      g.record(ctx, c, audit.LoginBlocked, key, map[string]any{"reason": "locked", "retry_after": ratelimit.RetrySeconds(d)})
+      return d
+    }
+  }
+  res, err := g.Store.Take(ctx, scope+":acct:"+key, g.Account)
+  if err != nil { log.Printf("auth guard: %v", err); return 0 }
+  if !res.Allowed {
+    g.record(ctx, c, audit.LoginBlocked, key, map[string]any{"reason": "rate_limit", "scope": scope})
+    return res.RetryAfter
+  }
+  return 0
+}
+
+// failure records a failed login; returns the lock it started (0 if none).
+func (g Guard) failure(c echo.Context, email string) time.Duration {
+  if g.Store == nil || email == "" { return 0 }
+  ctx := c.Request().Context()
+  key := accountKey(email)
+
+  n, lock, err := g.Lockout.Failure(ctx, key)
+  if err != nil { log.Printf("auth guard: %v", err) }
+  g.record(ctx, c, audit.LoginFailed, key, map[string]any{"failures": n})
+  if lock > 0 {
+    g.record(ctx, c, audit.AccountLocked, key, map[string]any{"failures": n, "locked_seconds": ratelimit.RetrySeconds(lock)})
+  }
+  return lock
+}
+
+func (g Guard) success(c echo.Context, email string) {
+  if g.Store == nil { return }
+  if err := g.Lockout.Success(c.Request().Context(), accountKey(email)); err != nil {
+    log.Printf("auth guard: %v", err)
+  }
+}
+
+func (g Guard) record(ctx context.Context, c echo.Context, action, email string, meta map[string]any) {
+  if g.Audit == nil { return }
+  meta["email"] = email
+  err := audit.Record(ctx, g.Audit, audit.Event{
+    Action: action, Entity: "users", Meta: meta, IP: c.RealIP(),
+  })
+  if err != nil { log.Printf("auth guard: audit %s: %v", action, err) }
+}
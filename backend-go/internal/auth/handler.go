@@ -1,35 +1,199 @@
 package auth
 
 import (
+  "errors"
+  "log"
   "net/http"
+  "strconv"
 
   "github.com/labstack/echo/v4"
+
+  "unichance-backend-go/internal/middleware"
+  "unichance-backend-go/internal/ratelimit"
 )
 
-type Handler struct { Svc Service }
+type Handler struct {
+  Svc Service
+  Guard Guard
+}
 
 type authReq struct {
   Email string `json:"email"`
   Password string `json:"password"`
 }
 
+type refreshReq struct {
+  RefreshToken string `json:"refresh_token"`
+}
+
+type tokenReq struct {
+  Token string `json:"token"`
+  Password string `json:"password"`
+}
+
+func client(c echo.Context) Client {
+  return Client{UserAgent: c.Request().UserAgent(), IP: c.RealIP()}
+}
+
 func (h Handler) Register(c echo.Context) error {
   var req authReq
   if err := c.Bind(&req); err != nil { return c.JSON(http.StatusBadRequest, map[string]string{"error":"bad body"}) }
-  token, user, err := h.Svc.Register(c.Request().Context(), req.Email, req.Password)
+  tokens, user, err := h.Svc.Register(c.Request().Context(), req.Email, req.Password, client(c))
   if err != nil { return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()}) }
-  return c.JSON(http.StatusCreated, map[string]any{"token": token, "user": user})
+  return c.JSON(http.StatusCreated, tokenResp(tokens, user))
 }
 
 func (h Handler) Login(c echo.Context) error {
   var req authReq
   if err := c.Bind(&req); err != nil { return c.JSON(http.StatusBadRequest, map[string]string{"error":"bad body"}) }
-  token, user, err := h.Svc.Login(c.Request().Context(), req.Email, req.Password)
-  if err != nil { return c.JSON(http.StatusUnauthorized, map[string]string{"error":"invalid credentials"}) }
-  return c.JSON(http.StatusOK, map[string]any{"token": token, "user": user})
+  if wait := h.Guard.allow(c, "login", req.Email); wait > 0 { return middleware.TooManyRequests(c, wait) }
+
+  tokens, user, err := h.Svc.Login(c.Request().Context(), req.Email, req.Password, client(c))
+  if err != nil {
+    if lock := h.Guard.failure(c, req.Email); lock > 0 {
+      c.Response().Header().Set("Retry-After", strconv.Itoa(ratelimit.RetrySeconds(lock)))
+    }
+    return c.JSON(http.StatusUnauthorized, map[string]string{"error":"invalid credentials"})
+  }
+  h.Guard.success(c, req.Email)
+  return c.JSON(http.StatusOK, tokenResp(tokens, user))
+}
+
+// Refresh — POST /auth/refresh {refresh_token}; returns a new pair, the old refresh token is spent.
+func (h Handler) Refresh(c echo.Context) error {
+  var req refreshReq
+  if err := c.Bind(&req); err != nil || req.RefreshToken == "" {
+    return c.JSON(http.StatusBadRequest, map[string]string{"error":"refresh_token required"})
+  }
+  tokens, user, err := h.Svc.Refresh(c.Request().Context(), req.RefreshToken, client(c))
+  if err != nil { return c.JSON(http.StatusUnauthorized, map[string]string{"error": ErrInvalidRefresh.Error()}) }
+  return c.JSON(http.StatusOK, tokenResp(tokens, user))
+}
+
+// Logout — POST /auth/logout {refresh_token}; ends this device's session.
+func (h Handler) Logout(c echo.Context) error {
+  var req refreshReq
+  if err := c.Bind(&req); err != nil || req.RefreshToken == "" {
+    return c.JSON(http.StatusBadRequest, map[string]string{"error":"refresh_token required"})
+  }
+  if err := h.Svc.Logout(c.Request().Context(), req.RefreshToken); err != nil {
+    return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+  }
+  return c.NoContent(http.StatusNoContent)
+}
+
+// LogoutAll — POST /auth/logout-all; every session and access token of the user.
+func (h Handler) LogoutAll(c echo.Context) error {
+  u := c.Get("user").(middleware.CtxUser)
+  if err := h.Svc.LogoutAll(c.Request().Context(), u.ID); err != nil {
+    return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+  }
+  return c.NoContent(http.StatusNoContent)
+}
+
+// VerifyEmail — POST /auth/verify-email {token}
+func (h Handler) VerifyEmail(c echo.Context) error {
+  var req tokenReq
+  if err := c.Bind(&req); err != nil || req.Token == "" {
+    return c.JSON(http.StatusBadRequest, map[string]string{"error":"token required"})
+  }
+  if err := h.Svc.VerifyEmail(c.Request().Context(), req.Token); err != nil {
+    return emailTokenErr(c, err)
+  }
+  return c.JSON(http.StatusOK, map[string]any{"email_verified": true})
+}
+
+// ResendVerification — POST /auth/verify-email/resend (auth)
+func (h Handler) ResendVerification(c echo.Context) error {
+  u := c.Get("user").(middleware.CtxUser)
+  err := h.Svc.ResendVerification(c.Request().Context(), u.ID)
+  if errors.Is(err, ErrAlreadyVerified) { return c.JSON(http.StatusConflict, map[string]string{"error": err.Error()}) }
+  if err != nil { return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()}) }
+  return c.NoContent(http.StatusAccepted)
+}
+
+// ForgotPassword — POST /auth/password/forgot {email}; always 202 so accounts can't be probed.
+func (h Handler) ForgotPassword(c echo.Context) error {
+  var req authReq
+  if err := c.Bind(&req); err != nil || req.Email == "" {
+    return c.JSON(http.StatusBadRequest, map[string]string{"error":"email required"})
+  }
+  // лимиттен асса да 202: жауап аккаунт туралы ештеңе айтпауы керек
+  if h.Guard.allow(c, "forgot", req.Email) > 0 { return c.NoContent(http.StatusAccepted) }
+  if err := h.Svc.RequestPasswordReset(c.Request().Context(), req.Email); err != nil {
+    log.Printf("auth: password reset request: %v", err)
+  }
+  return c.NoContent(http.StatusAccepted)
+}
+
+// ResetPassword — POST /auth/password/reset {token, password}; ends all sessions.
+func (h Handler) ResetPassword(c echo.Context) error {
+  var req tokenReq
+  if err := c.Bind(&req); err != nil || req.Token == "" {
+    return c.JSON(http.StatusBadRequest, map[string]string{"error":"token required"})
+  }
+  if err := h.Svc.ResetPassword(c.Request().Context(), req.Token, req.Password); err != nil {
+    return emailTokenErr(c, err)
+  }
+  return c.NoContent(http.StatusNoContent)
+}
+
+func emailTokenErr(c echo.Context, err error) error {
+  switch {
+  case errors.Is(err, ErrInvalidEmailToken), errors.Is(err, ErrWeakPassword):
+    return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+  }
+  return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
 }
 
 func (h Handler) Me(c echo.Context) error {
   u := c.Get("user")
   return c.JSON(http.StatusOK, map[string]any{"user": u})
 }
+
+func tokenResp(t Tokens, u User) map[string]any {
+  return map[string]any{
+    "token": t.Token,
+    "refresh_token": t.RefreshToken,
+    "expires_in": t.ExpiresIn,
+    "token_type": t.TokenType,
+    "user": u,
+  }
+}
+
+// SetRole — PUT /admin/users/:id/role {role} (admin)
+func (h Handler) SetRole(c echo.Context) error {
+  var req struct { Role string `json:"role"` }
+  if err := c.Bind(&req); err != nil { return c.JSON(http.StatusBadRequest, map[string]string{"error":"bad body"}) }
+  u, err := h.Svc.SetRole(c.Request().Context(), c.Param("id"), req.Role)
+  if err != nil { return roleErr(c, err) }
+  return c.JSON(http.StatusOK, map[string]any{"user": u})
+}
+
+// AssignStudent — PUT /admin/counselors/:id/students/:student_id (admin)
+func (h Handler) AssignStudent(c echo.Context) error {
+  if err := h.Svc.AssignStudent(c.Request().Context(), c.Param("id"), c.Param("student_id")); err != nil {
+    return roleErr(c, err)
+  }
+  return c.NoContent(http.StatusNoContent)
+}
+
+// UnassignStudent — DELETE /admin/counselors/:id/students/:student_id (admin)
+func (h Handler) UnassignStudent(c echo.Context) error {
+  if err := h.Svc.UnassignStudent(c.Request().Context(), c.Param("id"), c.Param("student_id")); err != nil {
+    return roleErr(c, err)
+  }
+  return c.NoContent(http.StatusNoContent)
+}
+
+func roleErr(c echo.Context, err error) error {
+  switch {
+  case errors.Is(err, ErrUserNotFound):
+    return c.JSON(http.StatusNotFound, map[string]string{"error": err.Error()})
+  case errors.Is(err, ErrInvalidRole), errors.Is(err, ErrNotCounselor), errors.Is(err, ErrNotStudent):
+    return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+  case errors.Is(err, ErrLastAdmin):
+    return c.JSON(http.StatusConflict, map[string]string{"error": err.Error()})
+  }
+  return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+}
@@ -0,0 +1,140 @@
+package auth
+
+import (
+  "context"
+  "crypto/rand"
+  "crypto/sha256"
+  "encoding/base64"
+  "encoding/hex"
+  "errors"
+  "time"
+
+  "github.com/jackc/pgx/v5"
+)
+
+var ErrInvalidRefresh = errors.New("invalid refresh token")
+
+// startSession issues an access token and the first refresh token of a new family.
+func (s Service) startSession(ctx context.Context, u User, ver int, cl Client) (Tokens, error) {
+  var family string
+  if err := s.DB.QueryRow(ctx, `SELECT gen_random_uuid()::text`).Scan(&family); err != nil {
+    return Tokens{}, err
+  }
+  refresh, _, err := s.insertRefresh(ctx, s.DB, u.ID, family, cl)
+  if err != nil { return Tokens{}, err }
+  return s.tokens(u, ver, refresh)
+}
+
+// Refresh rotates a refresh token: the presented token is revoked and a new
+// one from the same family is returned. Presenting an already rotated token
+// means it leaked, so the whole family (that login session) is revoked.
+func (s Service) Refresh(ctx context.Context, refreshToken string, cl Client) (Tokens, User, error) {
+  tx, err := s.DB.Begin(ctx)
+  if err != nil { return Tokens{}, User{}, err }
+  defer tx.Rollback(ctx)
+
+  var id, family string
+  var u User
+  var ver int
+  var expires time.Time
+  var revoked *time.Time
+  err = tx.QueryRow(ctx, `
+    SELECT rt.id, rt.family_id, rt.expires_at, rt.revoked_at,
+      u.id, u.email, u.email_verified_at IS NOT NULL, u.role, u.token_version
+    FROM refresh_tokens rt
+    JOIN users u ON u.id = rt.user_id
+    WHERE rt.token_hash = $1
+    FOR UPDATE OF rt
+  `, hashToken(refreshToken)).Scan(&id, &family, &expires, &revoked, &u.ID, &u.Email, &u.EmailVerified, &u.Role, &ver)
+  if errors.Is(err, pgx.ErrNoRows) { return Tokens{}, User{}, ErrInvalidRefresh }
+  if err != nil { return Tokens{}, User{}, err }
+
+  switch refreshDecision(revoked, expires, time.Now()) {
+  case refreshReuse:
+    // reuse: ұрланған токен болуы мүмкін — сессияны толық жабамыз
+    if _, err := tx.Exec(ctx, `UPDATE refresh_tokens SET revoked_at=now() WHERE family_id=$1 AND revoked_at IS NULL`, family); err != nil {
+      return Tokens{}, User{}, err
+    }
+    if err := tx.Commit(ctx); err != nil { return Tokens{}, User{}, err }
+    return Tokens{}, User{}, ErrInvalidRefresh
+  case refreshExpired:
+    return Tokens{}, User{}, ErrInvalidRefresh
+  }
+
+  refresh, newID, err := s.insertRefresh(ctx, tx, u.ID, family, cl)
+  if err != nil { return Tokens{}, User{}, err }
+  if _, err := tx.Exec(ctx, `UPDATE refresh_tokens SET revoked_at=now(), replaced_by=$2 WHERE id=$1`, id, newID); err != nil {
+    return Tokens{}, User{}, err
+  }
+  if err := tx.Commit(ctx); err != nil { return Tokens{}, User{}, err }
+
+  tokens, err := s.tokens(u, ver, refresh)
+  if err != nil { return Tokens{}, User{}, err }
+  return tokens, u, nil
+}
+
+type refreshAction int
+
+const (
+  refreshRotate refreshAction = iota // жаңа токен, ұсынылғаны revoked
+  refreshReuse // бұрын айналдырылған токен: бүкіл family жабылады
+  refreshExpired
+)
+
+// refreshDecision decides what Refresh does with a presented token. Reuse is
+// checked first so a leaked token is caught even after it expired.
+func refreshDecision(revoked *time.Time, expires, now time.Time) refreshAction {
+  if revoked != nil { return refreshReuse }
+  if now.After(expires) { return refreshExpired }
+  return refreshRotate
+}
+
+// Logout revokes the session (refresh token family) the token belongs to.
+// Unknown tokens are ignored so logout is idempotent.
+func (s Service) Logout(ctx context.Context, refreshToken string) error {
+  _, err := s.DB.Exec(ctx, `
+    UPDATE refresh_tokens SET revoked_at=now()
+    WHERE revoked_at IS NULL AND family_id = (SELECT family_id FROM refresh_tokens WHERE token_hash=$1)
+  `, hashToken(refreshToken))
+  return err
+}
+
+// rowQuerier — *pgxpool.Pool немесе pgx.Tx.
+type rowQuerier interface {
+  QueryRow(ctx context.Context, sql string, args ...any) pgx.Row
+}
+
+func (s Service) insertRefresh(ctx context.Context, db rowQuerier, userID, family string, cl Client) (string, string, error) {
+  token, err := randomToken()
+  if err != nil { return "", "", err }
+
+  var id string
+  err = db.QueryRow(ctx, `
+    INSERT INTO refresh_tokens(user_id, token_hash, family_id, expires_at, user_agent, ip)
+    VALUES ($1,$2,$3,$4,NULLIF($5,''),NULLIF($6,''))
+    RETURNING id
+  `, userID, hashToken(token), family, time.Now().Add(s.refreshTTL()), cl.UserAgent, cl.IP).Scan(&id)
+  return token, id, err
+}
+
+func (s Service) tokens(u User, ver int, refresh string) (Tokens, error) {
+  access, err := s.issueToken(u, ver)
+  if err != nil { return Tokens{}, err }
+  return Tokens{
+    Token: access,
+    RefreshToken: refresh,
+    ExpiresIn: int(s.accessTTL().Seconds()),
+    TokenType: "Bearer",
+  }, nil
+}
+
+func randomToken() (string, error) {
+  b := make([]byte, 32)
+  if _, err := rand.Read(b); err != nil { return "", err }
+  return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+func hashToken(token string) string {
+  sum := sha256.Sum256([]byte(token))
+  return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,74 @@
+package auth
+
+import (
+  "testing"
+  "time"
+
+  "github.com/golang-jwt/jwt/v5"
+)
+
+func TestRefreshDecision(t *testing.T) {
+  now := time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC)
+  revoked := now.Add(-time.Minute)
+
+  tests := []struct {
+    name string
+    revoked *time.Time
+    expires time.Time
+    want refreshAction
+  }{
+    {"active token rotates", nil, now.Add(time.Hour), refreshRotate},
+    {"expires exactly now", nil, now, refreshRotate},
+    {"expired token", nil, now.Add(-time.Second), refreshExpired},
+    {"rotated token presented again", &revoked, now.Add(time.Hour), refreshReuse},
+    {"reuse wins over expiry", &revoked, now.Add(-time.Hour), refreshReuse},
+  }
+  for _, tt := range tests {
+    if got := refreshDecision(tt.revoked, tt.expires, now); got != tt.want {
+      t.Errorf("%s: got %d, want %d", tt.name, got, tt.want)
+    }
+  }
+}
+
+func TestTokens(t *testing.T) {
+  tests := []struct {
+    name string
+    ttl time.Duration
+    expiresIn int
+  }{
+    {"configured ttl", 5 * time.Minute, 300},
+    {"default ttl", 0, int(DefaultAccessTTL.Seconds())},
+  }
+  u := User{ID: "u1", Email: "a@example.com", EmailVerified: true, Role: "counselor"}
+  for _, tt := range tests {
+    s := Service{JwtSecret: "secret", AccessTTL: tt.ttl}
+    got, err := s.tokens(u, 7, "refresh")
+    if err != nil { t.Fatalf("%s: %v", tt.name, err) }
+    if got.RefreshToken != "refresh" || got.TokenType != "Bearer" || got.ExpiresIn != tt.expiresIn {
+      t.Errorf("%s: tokens = %+v", tt.name, got)
+    }
+
+    claims := jwt.MapClaims{}
+    if _, err := jwt.ParseWithClaims(got.Token, claims, func(*jwt.Token) (any, error) { return []byte("secret"), nil }); err != nil {
+      t.Fatalf("%s: parse: %v", tt.name, err)
+    }
+    if claims["sub"] != "u1" || claims["role"] != "counselor" || claims["ver"] != float64(7) || claims["email_verified"] != true {
+      t.Errorf("%s: claims = %v", tt.name, claims)
+    }
+  }
+}
+
+func TestRefreshTokenHashing(t *testing.T) {
+  seen := map[string]bool{}
+  for i := 0; i < 100; i++ {
+    tok, err := randomToken()
+    if err != nil { t.Fatal(err) }
+    if len(tok) != 43 { t.Fatalf("randomToken length %d, want 43", len(tok)) }
+    if seen[tok] { t.Fatalf("randomToken repeated %q", tok) }
+    seen[tok] = true
+
+    h := hashToken(tok)
+    if len(h) != 64 || h != hashToken(tok) { t.Fatalf("hashToken(%q) = %q", tok, h) }
+    if h == tok { t.Fatal("hashToken returned the token") }
+  }
+}
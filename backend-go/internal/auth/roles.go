@@ -0,0 +1,97 @@
+package auth
+
+import (
+  "context"
+  "errors"
+  "strings"
+
+  "github.com/jackc/pgx/v5"
+
+  "unichance-backend-go/internal/middleware"
+)
+
+var (
+  ErrInvalidRole = errors.New("role must be student, counselor or admin")
+  ErrUserNotFound = errors.New("user not found")
+  ErrNotCounselor = errors.New("user is not a counselor")
+  ErrNotStudent = errors.New("user is not a student")
+  ErrLastAdmin = errors.New("cannot remove the last admin")
+)
+
+// SetRole changes users.role. token_version is bumped so access tokens with the
+// old role stop working; the next /auth/refresh issues one with the new role.
+// The last admin cannot be demoted (see cmd/setrole for bootstrapping one).
+func (s Service) SetRole(ctx context.Context, userID, role string) (User, error) {
+  if !middleware.ValidRole(role) { return User{}, ErrInvalidRole }
+
+  tx, err := s.DB.Begin(ctx)
+  if err != nil { return User{}, err }
+  defer tx.Rollback(ctx)
+
+  // барлық admin жолдарын құлыптаймыз: екі admin бір-бірін қатар түсіре алмайды
+  var admins []string
+  rows, err := tx.Query(ctx, `SELECT id::text FROM users WHERE role=$1 ORDER BY id FOR UPDATE`, middleware.RoleAdmin)
+  if err != nil { return User{}, err }
+  for rows.Next() {
+    var id string
+    if err := rows.Scan(&id); err != nil { rows.Close(); return User{}, err }
+    admins = append(admins, id)
+  }
+  rows.Close()
+  if err := rows.Err(); err != nil { return User{}, err }
+  if role != middleware.RoleAdmin && len(admins) == 1 && admins[0] == userID {
+    return User{}, ErrLastAdmin
+  }
+
+  u := User{ID: userID, Role: role}
+  err = tx.QueryRow(ctx, `
+    UPDATE users SET role=$2, token_version = token_version + CASE WHEN role <> $2 THEN 1 ELSE 0 END
+    WHERE id=$1
+    RETURNING email, email_verified_at IS NOT NULL
+  `, userID, role).Scan(&u.Email, &u.EmailVerified)
+  if errors.Is(err, pgx.ErrNoRows) { return User{}, ErrUserNotFound }
+  if err != nil { return User{}, err }
+
+  // студент емес болса, кеңесші байланыстары мағынасыз
+  if role != middleware.RoleStudent {
+    if _, err := tx.Exec(ctx, `DELETE FROM counselor_students WHERE student_id=$1`, userID); err != nil { return User{}, err }
+  }
+  if role != middleware.RoleCounselor {
+    if _, err := tx.Exec(ctx, `DELETE FROM counselor_students WHERE counselor_id=$1`, userID); err != nil { return User{}, err }
+  }
+  return u, tx.Commit(ctx)
+}
+
+// SetRoleByEmail — SetRole for an account looked up by email (cmd/setrole).
+func (s Service) SetRoleByEmail(ctx context.Context, email, role string) (User, error) {
+  var id string
+  err := s.DB.QueryRow(ctx, `SELECT id::text FROM users WHERE email=$1`, strings.TrimSpace(email)).Scan(&id)
+  if errors.Is(err, pgx.ErrNoRows) { return User{}, ErrUserNotFound }
+  if err != nil { return User{}, err }
+  return s.SetRole(ctx, id, role)
+}
+
+// AssignStudent gives a counselor read access to a student's profile.
+func (s Service) AssignStudent(ctx context.Context, counselorID, studentID string) error {
+  if err := s.checkRole(ctx, counselorID, middleware.RoleCounselor, ErrNotCounselor); err != nil { return err }
+  if err := s.checkRole(ctx, studentID, middleware.RoleStudent, ErrNotStudent); err != nil { return err }
+  _, err := s.DB.Exec(ctx, `
+    INSERT INTO counselor_students(counselor_id, student_id) VALUES ($1,$2)
+    ON CONFLICT DO NOTHING
+  `, counselorID, studentID)
+  return err
+}
+
+func (s Service) UnassignStudent(ctx context.Context, counselorID, studentID string) error {
+  _, err := s.DB.Exec(ctx, `DELETE FROM counselor_students WHERE counselor_id=$1 AND student_id=$2`, counselorID, studentID)
+  return err
+}
+
+func (s Service) checkRole(ctx context.Context, userID, want string, mismatch error) error {
+  var role string
+  err := s.DB.QueryRow(ctx, `SELECT role FROM users WHERE id=$1`, userID).Scan(&role)
+  if errors.Is(err, pgx.ErrNoRows) { return ErrUserNotFound }
+  if err != nil { return err }
+  if role != want { return mismatch }
+  return nil
+}
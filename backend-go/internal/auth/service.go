@@ -2,64 +2,152 @@ package auth
 
 import (
   "context"
+  netmail "net/mail"
+  "strings"
   "time"
 
   "github.com/golang-jwt/jwt/v5"
   "github.com/jackc/pgx/v5/pgxpool"
   "golang.org/x/crypto/bcrypt"
+
+  "unichance-backend-go/internal/mail"
+  "unichance-backend-go/internal/middleware"
+)
+
+const (
+  DefaultAccessTTL = 15 * time.Minute
+  DefaultRefreshTTL = 30 * 24 * time.Hour
 )
 
 type Service struct {
   DB *pgxpool.Pool
   JwtSecret string
+  AccessTTL time.Duration // 0 болса DefaultAccessTTL
+  RefreshTTL time.Duration // 0 болса DefaultRefreshTTL
+  Mailer mail.Mailer // nil болса mail.LogMailer
+  AppURL string // email сілтемелері үшін frontend адресі
 }
 
 type User struct {
   ID string `json:"id"`
   Email string `json:"email"`
+  EmailVerified bool `json:"email_verified"`
+  Role string `json:"role"`
+}
+
+// Tokens — login/register/refresh жауабы. Token = access JWT (ескі клиенттер үшін атауы сақталды).
+type Tokens struct {
+  Token string `json:"token"`
+  RefreshToken string `json:"refresh_token"`
+  ExpiresIn int `json:"expires_in"` // access токеннің секундтары
+  TokenType string `json:"token_type"`
 }
 
-func (s Service) Register(ctx context.Context, email, password string) (string, User, error) {
+// Client — refresh токенге жазылатын құрылғы ақпараты.
+type Client struct {
+  UserAgent string
+  IP string
+}
+
+func (s Service) Register(ctx context.Context, email, password string, cl Client) (Tokens, User, error) {
+  email = strings.TrimSpace(email)
+  if !validEmail(email) { return Tokens{}, User{}, ErrInvalidEmail }
+  if len(password) < MinPasswordLen { return Tokens{}, User{}, ErrWeakPassword }
+
   hash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
-  if err != nil { return "", User{}, err }
+  if err != nil { return Tokens{}, User{}, err }
 
   var id string
   err = s.DB.QueryRow(ctx,
     `INSERT INTO users(email,password_hash) VALUES ($1,$2) RETURNING id`,
     email, string(hash),
   ).Scan(&id)
-  if err != nil { return "", User{}, err }
+  if err != nil { return Tokens{}, User{}, err }
 
-  token, err := s.issueToken(id, email)
-  if err != nil { return "", User{}, err }
+  u := User{ID:id, Email:email, Role:middleware.RoleStudent}
+  tokens, err := s.startSession(ctx, u, 0, cl)
+  if err != nil { return Tokens{}, User{}, err }
+
+  // хат фонда кетеді: баяу SMTP тіркелу жауабын ұстамауы керек
+  go func() {
+    ctx, cancel := context.WithTimeout(context.Background(), mailTimeout)
+    defer cancel()
+    logMailErr("verification email", s.sendVerification(ctx, u))
+  }()
+  return tokens, u, nil
+}
 
-  return token, User{ID:id, Email:email}, nil
+// validEmail — жалғыз "local@domain" адрес, атауы/бұрыштық жақшасыз.
+func validEmail(email string) bool {
+  if len(email) > 254 { return false }
+  a, err := netmail.ParseAddress(email)
+  return err == nil && a.Address == email && strings.Contains(email[strings.LastIndex(email, "@"):], ".")
 }
 
-func (s Service) Login(ctx context.Context, email, password string) (string, User, error) {
+func (s Service) Login(ctx context.Context, email, password string, cl Client) (Tokens, User, error) {
+  email = strings.TrimSpace(email) // Register сияқты, әйтпесе " a@b.kz" табылмайды
   var id, hash string
+  var ver int
+  var verified bool
+  var role string
   err := s.DB.QueryRow(ctx,
-    `SELECT id, password_hash FROM users WHERE email=$1`,
+    `SELECT id, password_hash, token_version, email_verified_at IS NOT NULL, role FROM users WHERE email=$1`,
     email,
-  ).Scan(&id, &hash)
-  if err != nil { return "", User{}, err }
+  ).Scan(&id, &hash, &ver, &verified, &role)
+  if err != nil { return Tokens{}, User{}, err }
 
   if err := bcrypt.CompareHashAndPassword([]byte(hash), []byte(password)); err != nil {
-    return "", User{}, err
+    return Tokens{}, User{}, err
   }
 
-  token, err := s.issueToken(id, email)
-  if err != nil { return "", User{}, err }
+  u := User{ID:id, Email:email, EmailVerified:verified, Role:role}
+  tokens, err := s.startSession(ctx, u, ver, cl)
+  if err != nil { return Tokens{}, User{}, err }
+  return tokens, u, nil
+}
+
+// TokenVersion implements middleware.TokenVersions.
+func (s Service) TokenVersion(ctx context.Context, userID string) (int, error) {
+  var ver int
+  err := s.DB.QueryRow(ctx, `SELECT token_version FROM users WHERE id=$1`, userID).Scan(&ver)
+  return ver, err
+}
+
+// LogoutAll revokes every refresh token of the user and bumps token_version,
+// so access tokens already issued stop working immediately.
+func (s Service) LogoutAll(ctx context.Context, userID string) error {
+  tx, err := s.DB.Begin(ctx)
+  if err != nil { return err }
+  defer tx.Rollback(ctx)
+
+  if _, err := tx.Exec(ctx, `UPDATE users SET token_version = token_version + 1 WHERE id=$1`, userID); err != nil {
+    return err
+  }
+  if _, err := tx.Exec(ctx, `UPDATE refresh_tokens SET revoked_at=now() WHERE user_id=$1 AND revoked_at IS NULL`, userID); err != nil {
+    return err
+  }
+  return tx.Commit(ctx)
+}
+
+func (s Service) accessTTL() time.Duration {
+  if s.AccessTTL <= 0 { return DefaultAccessTTL }
+  return s.AccessTTL
+}
 
-  return token, User{ID:id, Email:email}, nil
+func (s Service) refreshTTL() time.Duration {
+  if s.RefreshTTL <= 0 { return DefaultRefreshTTL }
+  return s.RefreshTTL
 }
 
-func (s Service) issueToken(userID, email string) (string, error) {
+func (s Service) issueToken(u User, ver int) (string, error) {
   claims := jwt.MapClaims{
-    "sub": userID,
-    "email": email,
+    "sub": u.ID,
+    "email": u.Email,
+    "email_verified": u.EmailVerified,
+    "role": u.Role,
+    "ver": ver,
     "iat": time.Now().Unix(),
-    "exp": time.Now().Add(7*24*time.Hour).Unix(),
+    "exp": time.Now().Add(s.accessTTL()).Unix(),
   }
   t := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
   return t.SignedString([]byte(s.JwtSecret))
@@ -1,11 +1,30 @@
 package config
 
-import "os"
+import (
+  "os"
+  "time"
+)
 
 type Config struct {
   DatabaseURL string
   JwtSecret   string
   Port        string
+
+  ScoringPolicy     string // scoring_policies.name (active version)
+  ScoringPolicyFile string // JSON файл; берілсе DB-дан басым
+
+  AccessTokenTTL  time.Duration // ACCESS_TOKEN_TTL, мысалы "15m"; 0 = auth әдепкісі
+  RefreshTokenTTL time.Duration // REFRESH_TOKEN_TTL, мысалы "720h"
+
+  AppURL       string // APP_URL — email сілтемелеріндегі frontend адресі
+  SMTPHost     string // бос болса хаттар логқа / MailDir-ге жазылады
+  SMTPPort     string
+  SMTPUser     string
+  SMTPPassword string
+  MailFrom     string
+  MailDir      string
+
+  TrustProxy bool // TRUST_PROXY=true: client IP X-Forwarded-For-тан (rate limit, audit)
 }
 
 func Load() Config {
@@ -13,7 +32,22 @@ func Load() Config {
     DatabaseURL: os.Getenv("DATABASE_URL"),
     JwtSecret:   os.Getenv("JWT_SECRET"),
     Port:        os.Getenv("PORT"),
+
+    ScoringPolicy:     os.Getenv("SCORING_POLICY"),
+    ScoringPolicyFile: os.Getenv("SCORING_POLICY_FILE"),
+
+    AppURL:       os.Getenv("APP_URL"),
+    SMTPHost:     os.Getenv("SMTP_HOST"),
+    SMTPPort:     os.Getenv("SMTP_PORT"),
+    SMTPUser:     os.Getenv("SMTP_USER"),
+    SMTPPassword: os.Getenv("SMTP_PASSWORD"),
+    MailFrom:     os.Getenv("MAIL_FROM"),
+    MailDir:      os.Getenv("MAIL_DIR"),
   }
+  c.TrustProxy = os.Getenv("TRUST_PROXY") == "true"
+  if c.MailFrom == "" { c.MailFrom = "UniChance <noreply@unichance.local>" }
   if c.Port == "" { c.Port = "8080" }
+  c.AccessTokenTTL, _ = time.ParseDuration(os.Getenv("ACCESS_TOKEN_TTL"))
+  c.RefreshTokenTTL, _ = time.ParseDuration(os.Getenv("REFRESH_TOKEN_TTL"))
   return c
 }
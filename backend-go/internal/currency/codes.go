@@ -0,0 +1,63 @@
+package currency
+
+import (
+	"context"
+	"strings"
+)
+
+// Currency — currencies кестесінің жолы (ISO 4217).
+type Currency struct {
+	Code        string  `json:"code"`
+	NumericCode *string `json:"numeric_code"`
+	Name        string  `json:"name"`
+	MinorUnits  int     `json:"minor_units"`
+}
+
+// List returns active currencies ordered by code.
+func (r Repo) List(ctx context.Context) ([]Currency, error) {
+	rows, err := r.DB.Query(ctx, `
+		SELECT code, numeric_code, name, minor_units
+		FROM currencies
+		WHERE is_active
+		ORDER BY code
+	`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	out := []Currency{}
+	for rows.Next() {
+		var c Currency
+		if err := rows.Scan(&c.Code, &c.NumericCode, &c.Name, &c.MinorUnits); err != nil {
+			return nil, err
+		}
+		out = append(out, c)
+	}
+	return out, rows.Err()
+}
+
+// Codes returns the set of active currency codes.
+func (r Repo) Codes(ctx context.Context) (map[string]bool, error) {
+	items, err := r.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+	out := make(map[string]bool, len(items))
+	for _, c := range items {
+		out[c.Code] = true
+	}
+	return out, nil
+}
+
+// Known reports whether code is an active ISO 4217 code in currencies.
+func (r Repo) Known(ctx context.Context, code string) (bool, error) {
+	var ok bool
+	err := r.DB.QueryRow(ctx, `SELECT EXISTS(SELECT 1 FROM currencies WHERE code=$1 AND is_active)`, Normalize(code)).Scan(&ok)
+	return ok, err
+}
+
+// Normalize trims and upper-cases a currency code ("usd " → "USD").
+func Normalize(code string) string {
+	return strings.ToUpper(strings.TrimSpace(code))
+}
@@ -0,0 +1,17 @@
+package currency
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+)
+
+type Handler struct{ Repo Repo }
+
+func (h Handler) List(c echo.Context) error {
+	items, err := h.Repo.List(c.Request().Context())
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+	}
+	return c.JSON(http.StatusOK, map[string]any{"items": items})
+}
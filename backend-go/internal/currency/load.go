@@ -0,0 +1,93 @@
+package currency
+
+import (
+	"encoding/csv"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ParseCSV reads "date,base,quote,rate[,source]" rows (header required).
+func ParseCSV(r io.Reader) ([]Pair, error) {
+	cr := csv.NewReader(r)
+	cr.FieldsPerRecord = -1
+	rows, err := cr.ReadAll()
+	if err != nil {
+		return nil, err
+	}
+
+	var out []Pair
+	for i := 1; i < len(rows); i++ {
+		row := rows[i]
+		if len(row) < 4 {
+			return nil, fmt.Errorf("row %d: expected date,base,quote,rate", i+1)
+		}
+		p, err := newPair(row[0], row[1], row[2], row[3])
+		if err != nil {
+			return nil, fmt.Errorf("row %d: %w", i+1, err)
+		}
+		p.Source = "csv"
+		if len(row) > 4 && strings.TrimSpace(row[4]) != "" {
+			p.Source = strings.TrimSpace(row[4])
+		}
+		out = append(out, p)
+	}
+	return out, nil
+}
+
+// ecbEnvelope — https://www.ecb.europa.eu/stats/eurofxref/eurofxref-daily.xml
+// (and the -hist variant, which has one time cube per day).
+type ecbEnvelope struct {
+	Cube struct {
+		Days []struct {
+			Time  string `xml:"time,attr"`
+			Rates []struct {
+				Currency string `xml:"currency,attr"`
+				Rate     string `xml:"rate,attr"`
+			} `xml:"Cube"`
+		} `xml:"Cube"`
+	} `xml:"Cube"`
+}
+
+// ParseECB reads an ECB euro foreign exchange reference rates XML file.
+func ParseECB(r io.Reader) ([]Pair, error) {
+	var env ecbEnvelope
+	if err := xml.NewDecoder(r).Decode(&env); err != nil {
+		return nil, err
+	}
+
+	var out []Pair
+	for _, d := range env.Cube.Days {
+		for _, c := range d.Rates {
+			p, err := newPair(d.Time, "EUR", c.Currency, c.Rate)
+			if err != nil {
+				return nil, fmt.Errorf("%s %s: %w", d.Time, c.Currency, err)
+			}
+			p.Source = "ecb"
+			out = append(out, p)
+		}
+	}
+	if len(out) == 0 {
+		return nil, fmt.Errorf("no rates found")
+	}
+	return out, nil
+}
+
+func newPair(date, base, quote, rate string) (Pair, error) {
+	d, err := time.Parse("2006-01-02", strings.TrimSpace(date))
+	if err != nil {
+		return Pair{}, fmt.Errorf("bad date %q", date)
+	}
+	base, quote = strings.ToUpper(strings.TrimSpace(base)), strings.ToUpper(strings.TrimSpace(quote))
+	if len(base) != 3 || len(quote) != 3 || base == quote {
+		return Pair{}, fmt.Errorf("bad currency pair %s/%s", base, quote)
+	}
+	v, err := strconv.ParseFloat(strings.TrimSpace(rate), 64)
+	if err != nil || v <= 0 {
+		return Pair{}, fmt.Errorf("bad rate %q", rate)
+	}
+	return Pair{Date: d, Base: base, Quote: quote, Rate: v}, nil
+}
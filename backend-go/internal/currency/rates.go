@@ -0,0 +1,93 @@
+package currency
+
+import (
+	"strings"
+	"time"
+)
+
+// Base — бағамдар сақталатын және есептелетін базалық валюта (ECB сияқты).
+const Base = "EUR"
+
+// Default — display currency берілмегенде.
+const Default = "USD"
+
+// Rates — бір базалық валютаға қатысты бағамдар: 1 Base = PerBase[code] code.
+type Rates struct {
+	Base    string
+	PerBase map[string]float64
+	Date    time.Time // бағамдар күні; Fallback үшін бос
+}
+
+// Fallback — DB-да бағам жоқ кезде қолданылатын шамамен алынған бағамдар (EUR базасы).
+var Fallback = Rates{
+	Base: Base,
+	PerBase: map[string]float64{
+		"EUR": 1,
+		"USD": 1.08,
+		"KZT": 540,
+	},
+}
+
+// Convert converts amount between two currencies through Base.
+// ok is false when either rate is unknown.
+func (r Rates) Convert(amount float64, from, to string) (float64, bool) {
+	from, to = strings.ToUpper(from), strings.ToUpper(to)
+	if from == to {
+		return amount, true
+	}
+	rf, ok := r.rate(from)
+	if !ok {
+		return 0, false
+	}
+	rt, ok := r.rate(to)
+	if !ok {
+		return 0, false
+	}
+	return amount / rf * rt, true
+}
+
+// Has reports whether code can be converted.
+func (r Rates) Has(code string) bool {
+	_, ok := r.rate(strings.ToUpper(code))
+	return ok
+}
+
+// Codes returns the known currency codes and their per-base rates as two
+// parallel slices (the shape SQL unnest($1::text[], $2::float8[]) expects).
+func (r Rates) Codes() ([]string, []float64) {
+	codes := []string{r.Base}
+	rates := []float64{1}
+	for code, v := range r.PerBase {
+		if code == r.Base || v <= 0 {
+			continue
+		}
+		codes = append(codes, code)
+		rates = append(rates, v)
+	}
+	return codes, rates
+}
+
+// withFallback fills currencies missing from r with Fallback rates.
+func (r Rates) withFallback() Rates {
+	out := Rates{Base: r.Base, Date: r.Date, PerBase: map[string]float64{}}
+	for code, v := range r.PerBase {
+		out.PerBase[code] = v
+	}
+	for code := range Fallback.PerBase {
+		if out.Has(code) {
+			continue
+		}
+		if v, ok := Fallback.Convert(1, r.Base, code); ok {
+			out.PerBase[code] = v
+		}
+	}
+	return out
+}
+
+func (r Rates) rate(code string) (float64, bool) {
+	if code == r.Base {
+		return 1, true
+	}
+	v, ok := r.PerBase[code]
+	return v, ok && v > 0
+}
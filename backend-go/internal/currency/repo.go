@@ -0,0 +1,101 @@
+package currency
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// Pair — exchange_rates жолы: 1 Base = Rate Quote.
+type Pair struct {
+	Date   time.Time
+	Base   string
+	Quote  string
+	Rate   float64
+	Source string
+}
+
+type Repo struct{ DB *pgxpool.Pool }
+
+// Upsert writes pairs; an existing (base, quote, date) row is overwritten.
+func (r Repo) Upsert(ctx context.Context, pairs []Pair) (int, error) {
+	n := 0
+	for _, p := range pairs {
+		src := p.Source
+		if src == "" {
+			src = "manual"
+		}
+		_, err := r.DB.Exec(ctx, `
+			INSERT INTO exchange_rates(rate_date, base, quote, rate, source)
+			VALUES ($1,$2,$3,$4,$5)
+			ON CONFLICT (base, quote, rate_date) DO UPDATE SET
+				rate=EXCLUDED.rate,
+				source=EXCLUDED.source
+		`, p.Date, strings.ToUpper(p.Base), strings.ToUpper(p.Quote), p.Rate, src)
+		if err != nil {
+			return n, err
+		}
+		n++
+	}
+	return n, nil
+}
+
+// RatesAt returns the newest rate of every pair on or before date, resolved
+// to Base. Pairs quoted against another currency (e.g. USD/KZT from the
+// national bank) are chained through a currency that is already known.
+func (r Repo) RatesAt(ctx context.Context, date time.Time) (Rates, error) {
+	rows, err := r.DB.Query(ctx, `
+		SELECT DISTINCT ON (base, quote) rate_date, base, quote, rate::float8
+		FROM exchange_rates
+		WHERE rate_date <= $1
+		ORDER BY base, quote, rate_date DESC
+	`, date)
+	if err != nil {
+		return Rates{}, err
+	}
+	defer rows.Close()
+
+	var pairs []Pair
+	for rows.Next() {
+		var p Pair
+		if err := rows.Scan(&p.Date, &p.Base, &p.Quote, &p.Rate); err != nil {
+			return Rates{}, err
+		}
+		pairs = append(pairs, p)
+	}
+	if err := rows.Err(); err != nil {
+		return Rates{}, err
+	}
+	return resolve(Base, pairs), nil
+}
+
+// resolve builds Rates for base from arbitrary pairs. Direct pairs win;
+// the rest are derived (inverse or chained) until nothing new is learnt.
+func resolve(base string, pairs []Pair) Rates {
+	out := Rates{Base: base, PerBase: map[string]float64{}}
+	for changed := true; changed; {
+		changed = false
+		for _, p := range pairs {
+			if p.Rate <= 0 {
+				continue
+			}
+			rb, okB := out.rate(p.Base)
+			rq, okQ := out.rate(p.Quote)
+			switch {
+			case okB && !okQ:
+				out.PerBase[p.Quote] = rb * p.Rate
+			case okQ && !okB:
+				out.PerBase[p.Base] = rq / p.Rate
+			default:
+				continue
+			}
+			if p.Date.After(out.Date) {
+				out.Date = p.Date
+			}
+			changed = true
+		}
+	}
+	return out
+}
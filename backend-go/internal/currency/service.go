@@ -0,0 +1,86 @@
+package currency
+
+import (
+	"context"
+	"log"
+	"sync"
+	"time"
+
+	"golang.org/x/sync/singleflight"
+)
+
+// Service serves the latest rates from exchange_rates with a short in-memory
+// cache. Currencies missing from the table fall back to Fallback, so callers
+// always get something they can convert with.
+type Service struct {
+	Repo Repo
+	TTL  time.Duration // 0 болса 1 сағат
+
+	mu       sync.Mutex // cached/loadedAt ғана; DB сұрауы құлыптан тыс
+	cached   Rates
+	loadedAt time.Time
+	load     singleflight.Group
+}
+
+func NewService(repo Repo) *Service {
+	return &Service{Repo: repo, TTL: time.Hour}
+}
+
+// Current returns today's rates. A DB error is logged and the previous
+// (or Fallback) rates are returned.
+func (s *Service) Current(ctx context.Context) Rates {
+	if s == nil {
+		return Fallback
+	}
+	ttl := s.TTL
+	if ttl <= 0 {
+		ttl = time.Hour
+	}
+
+	if r, ok := s.fresh(ttl); ok {
+		return r
+	}
+	// кэш ескірсе бір ғана сұрау кетеді, қалғандары соның нәтижесін күтеді
+	v, _, _ := s.load.Do("current", func() (any, error) {
+		if r, ok := s.fresh(ttl); ok {
+			return r, nil
+		}
+		r, err := s.Repo.RatesAt(context.WithoutCancel(ctx), time.Now())
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		if err != nil {
+			log.Printf("exchange rates: %v", err)
+			if s.cached.PerBase != nil {
+				return s.cached, nil
+			}
+			return Fallback, nil
+		}
+		s.cached, s.loadedAt = r.withFallback(), time.Now()
+		return s.cached, nil
+	})
+	return v.(Rates)
+}
+
+func (s *Service) fresh(ttl time.Duration) (Rates, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.cached.PerBase != nil && time.Since(s.loadedAt) < ttl {
+		return s.cached, true
+	}
+	return Rates{}, false
+}
+
+// At returns rates as of date (no cache); used for historical figures.
+func (s *Service) At(ctx context.Context, date time.Time) (Rates, error) {
+	r, err := s.Repo.RatesAt(ctx, date)
+	if err != nil {
+		return Rates{}, err
+	}
+	return r.withFallback(), nil
+}
+
+// Convert converts with the current rates. Callers converting many amounts
+// should take Current once and use Rates (a scoring.Converter) directly.
+func (s *Service) Convert(ctx context.Context, amount float64, from, to string) (float64, bool) {
+	return s.Current(ctx).Convert(amount, from, to)
+}
@@ -0,0 +1,151 @@
+package grading
+
+import (
+	"sort"
+	"strconv"
+)
+
+// Default is the scale requirements.min_gpa is assumed to be on when
+// requirements.gpa_system is empty.
+const Default = "us_4"
+
+type point struct {
+	native float64
+	gpa4   float64
+}
+
+// System — бір ұлттық бағалау жүйесі және оның 4.0 шкаласына сәйкестік кестесі.
+// Кесте нүктелерінің арасы сызықтық интерполяцияланады; gpa4 қатаң монотонды
+// болғандықтан түрлендіру екі бағытта да дәл (ToGPA4 ↔ FromGPA4).
+type System struct {
+	Code     string  `json:"code"`
+	Name     string  `json:"name"`
+	Min      float64 `json:"min"`
+	Max      float64 `json:"max"`
+	Inverted bool    `json:"inverted"` // төмен мән = жақсы баға (Германия)
+
+	points []point // native бойынша өсу ретімен
+}
+
+var registry = map[string]System{}
+
+func register(code, name string, inverted bool, pts ...point) {
+	registry[code] = System{
+		Code: code, Name: name, Inverted: inverted,
+		Min: pts[0].native, Max: pts[len(pts)-1].native,
+		points: pts,
+	}
+}
+
+func init() {
+	register("us_4", "US GPA 4.0", false,
+		point{0, 0}, point{4, 4})
+	register("scale_5", "Linear 5.0", false,
+		point{0, 0}, point{5, 4})
+	register("pct_100", "Percentage 0–100", false,
+		point{0, 0}, point{60, 1.0}, point{70, 1.7}, point{73, 2.0}, point{77, 2.3}, point{80, 2.7},
+		point{83, 3.0}, point{87, 3.3}, point{90, 3.7}, point{93, 3.9}, point{100, 4.0})
+	register("kz_5", "Kazakhstan 5-point (2–5)", false,
+		point{2, 0}, point{3, 2.0}, point{4, 3.0}, point{5, 4.0})
+	register("ru_5", "Russia 5-point (2–5)", false,
+		point{2, 0}, point{3, 2.0}, point{4, 3.0}, point{5, 4.0})
+	register("de_5", "Germany 1.0–5.0 (1.0 best)", true,
+		point{1.0, 4.0}, point{1.5, 3.7}, point{2.0, 3.3}, point{2.5, 3.0}, point{3.0, 2.7},
+		point{3.5, 2.3}, point{4.0, 2.0}, point{5.0, 0})
+	register("uk_pct", "UK honours mark (%)", false,
+		point{0, 0}, point{40, 2.0}, point{50, 2.7}, point{60, 3.3}, point{70, 3.7}, point{100, 4.0})
+	register("uk_class", "UK classification (1=First, 2=2:1, 3=2:2, 4=Third)", true,
+		point{1, 3.8}, point{2, 3.3}, point{3, 2.8}, point{4, 2.2})
+	register("ib_45", "IB Diploma points (0–45)", false,
+		point{0, 0}, point{24, 2.0}, point{30, 3.0}, point{35, 3.5}, point{40, 3.9}, point{45, 4.0})
+}
+
+func Lookup(code string) (System, bool) {
+	s, ok := registry[code]
+	return s, ok
+}
+
+func Known(code string) bool {
+	_, ok := registry[code]
+	return ok
+}
+
+func All() []System {
+	out := make([]System, 0, len(registry))
+	for _, s := range registry {
+		out = append(out, s)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Code < out[j].Code })
+	return out
+}
+
+// ForScale maps the legacy profiles.gpa_scale number onto a system,
+// so profiles saved before grading_system existed keep working.
+func ForScale(scale float64) System {
+	switch scale {
+	case 4:
+		return registry["us_4"]
+	case 5:
+		return registry["scale_5"]
+	case 100:
+		return registry["pct_100"]
+	case 45:
+		return registry["ib_45"]
+	}
+	return System{
+		Code: "linear_" + strconv.FormatFloat(scale, 'f', -1, 64), Name: "Linear",
+		Min: 0, Max: scale,
+		points: []point{{0, 0}, {scale, 4}},
+	}
+}
+
+func (s System) Valid(v float64) bool {
+	return v >= s.Min && v <= s.Max
+}
+
+func (s System) ToGPA4(v float64) float64 {
+	pts := s.points
+	if v <= pts[0].native {
+		return pts[0].gpa4
+	}
+	for i := 1; i < len(pts); i++ {
+		if v <= pts[i].native {
+			return lerp(v, pts[i-1].native, pts[i].native, pts[i-1].gpa4, pts[i].gpa4)
+		}
+	}
+	return pts[len(pts)-1].gpa4
+}
+
+func (s System) FromGPA4(g float64) float64 {
+	pts := s.points
+	for i := 1; i < len(pts); i++ {
+		lo, hi := pts[i-1].gpa4, pts[i].gpa4
+		if lo > hi {
+			lo, hi = hi, lo
+		}
+		if g >= lo && g <= hi {
+			return lerp(g, pts[i-1].gpa4, pts[i].gpa4, pts[i-1].native, pts[i].native)
+		}
+	}
+	// кестеден тыс: ең жақын шеткі мән
+	best, worst := pts[len(pts)-1], pts[0]
+	if s.Inverted {
+		best, worst = worst, best
+	}
+	if g >= best.gpa4 {
+		return best.native
+	}
+	return worst.native
+}
+
+// Convert expresses v (on from's scale) on to's scale through 4.0.
+func Convert(v float64, from, to System) float64 {
+	return to.FromGPA4(from.ToGPA4(v))
+}
+
+func lerp(x, x0, x1, y0, y1 float64) float64 {
+	if x1 == x0 {
+		return y0
+	}
+	return y0 + (x-x0)*(y1-y0)/(x1-x0)
+}
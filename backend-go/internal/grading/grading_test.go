@@ -0,0 +1,131 @@
+package grading
+
+import (
+	"math"
+	"testing"
+)
+
+func approx(a, b float64) bool { return math.Abs(a-b) < 1e-9 }
+
+func mustLookup(t *testing.T, code string) System {
+	t.Helper()
+	s, ok := Lookup(code)
+	if !ok {
+		t.Fatalf("Lookup(%q): not registered", code)
+	}
+	return s
+}
+
+func TestToGPA4(t *testing.T) {
+	tests := []struct {
+		system string
+		native float64
+		want   float64
+	}{
+		{"us_4", 3.2, 3.2},
+		{"scale_5", 5, 4},
+		{"scale_5", 2.5, 2},
+		{"pct_100", 85, 3.15},
+		{"pct_100", 50, 50.0 / 60},
+		{"kz_5", 4.5, 3.5},
+		{"kz_5", 2, 0},
+		{"ib_45", 45, 4},
+		// de_5: 1.0 — ең жақсы
+		{"de_5", 1.0, 4.0},
+		{"de_5", 1.25, 3.85},
+		{"de_5", 4.5, 1.0},
+		{"de_5", 5.0, 0},
+		{"de_5", 0.5, 4.0}, // кестеден тыс
+		{"de_5", 6, 0},
+		// uk_class: 1 = First
+		{"uk_class", 1, 3.8},
+		{"uk_class", 1.5, 3.55},
+		{"uk_class", 4, 2.2},
+	}
+	for _, tt := range tests {
+		got := mustLookup(t, tt.system).ToGPA4(tt.native)
+		if !approx(got, tt.want) {
+			t.Errorf("%s.ToGPA4(%g) = %g, want %g", tt.system, tt.native, got, tt.want)
+		}
+	}
+}
+
+func TestFromGPA4(t *testing.T) {
+	tests := []struct {
+		system string
+		gpa4   float64
+		want   float64
+	}{
+		{"us_4", 3.2, 3.2},
+		{"pct_100", 3.15, 85},
+		{"kz_5", 3.5, 4.5},
+		{"de_5", 4.0, 1.0},
+		{"de_5", 3.85, 1.25},
+		{"de_5", 0, 5.0},
+		{"de_5", 4.5, 1.0}, // жоғары: ең жақсы баға (1.0)
+		{"de_5", -1, 5.0},  // төмен: ең нашар баға (5.0)
+		{"uk_class", 3.55, 1.5},
+		{"uk_class", 4.0, 1}, // First-тен жоғары
+		{"uk_class", 1.0, 4}, // Third-тен төмен
+	}
+	for _, tt := range tests {
+		got := mustLookup(t, tt.system).FromGPA4(tt.gpa4)
+		if !approx(got, tt.want) {
+			t.Errorf("%s.FromGPA4(%g) = %g, want %g", tt.system, tt.gpa4, got, tt.want)
+		}
+	}
+}
+
+// Every registered scale must round-trip through 4.0 inside its range.
+func TestRoundTrip(t *testing.T) {
+	for _, s := range All() {
+		for i := 0; i <= 20; i++ {
+			v := s.Min + (s.Max-s.Min)*float64(i)/20
+			if got := s.FromGPA4(s.ToGPA4(v)); !approx(got, v) {
+				t.Errorf("%s: FromGPA4(ToGPA4(%g)) = %g", s.Code, v, got)
+			}
+		}
+	}
+}
+
+func TestConvert(t *testing.T) {
+	tests := []struct {
+		from, to string
+		v, want  float64
+	}{
+		{"de_5", "us_4", 1.0, 4.0},
+		{"us_4", "de_5", 4.0, 1.0},
+		{"pct_100", "de_5", 85, 2.25},
+		{"uk_class", "kz_5", 1.5, 4.55},
+	}
+	for _, tt := range tests {
+		got := Convert(tt.v, mustLookup(t, tt.from), mustLookup(t, tt.to))
+		if !approx(got, tt.want) {
+			t.Errorf("Convert(%g, %s, %s) = %g, want %g", tt.v, tt.from, tt.to, got, tt.want)
+		}
+	}
+}
+
+func TestForScale(t *testing.T) {
+	tests := []struct {
+		scale float64
+		code  string
+		v     float64
+		gpa4  float64
+	}{
+		{4, "us_4", 3, 3},
+		{5, "scale_5", 5, 4},
+		{100, "pct_100", 90, 3.7},
+		{45, "ib_45", 30, 3},
+		{10, "linear_10", 7.5, 3},
+	}
+	for _, tt := range tests {
+		s := ForScale(tt.scale)
+		if s.Code != tt.code {
+			t.Errorf("ForScale(%g).Code = %q, want %q", tt.scale, s.Code, tt.code)
+		}
+		if got := s.ToGPA4(tt.v); !approx(got, tt.gpa4) {
+			t.Errorf("ForScale(%g).ToGPA4(%g) = %g, want %g", tt.scale, tt.v, got, tt.gpa4)
+		}
+	}
+}
@@ -1,16 +1,23 @@
 package http
 
 import (
+	"time"
+
 	"unichance-backend-go/internal/universities"
 
 	"github.com/labstack/echo/v4"
 	"github.com/labstack/echo/v4/middleware"
 	echoMw "github.com/labstack/echo/v4/middleware"
 
+	"unichance-backend-go/internal/admin"
 	"unichance-backend-go/internal/auth"
+	"unichance-backend-go/internal/currency"
+	"unichance-backend-go/internal/grading"
 	appMw "unichance-backend-go/internal/middleware"
 	"unichance-backend-go/internal/profile"
 	"unichance-backend-go/internal/programs"
+	"unichance-backend-go/internal/ratelimit"
+	"unichance-backend-go/internal/search"
 )
 
 type Deps struct {
@@ -18,44 +25,121 @@ type Deps struct {
 	ProgramsHandler     programs.Handler
 	ProfileHandler      profile.Handler
 	UniversitiesHandler universities.Handler
+	CurrenciesHandler   currency.Handler
+	SearchHandler       search.Handler
+	AdminHandler        admin.Handler
 	JwtSecret           string
+	TokenVersions       appMw.TokenVersions
+	RateLimits          ratelimit.Store // nil болса лимит жоқ
+	TrustProxy          bool            // X-Forwarded-For-қа сену (reverse proxy артында)
 }
 
+// Per-route limits (token bucket per key).
+var (
+	authLimit    = ratelimit.Per(20, time.Minute) // per IP: login/register/refresh/reset
+	searchLimit  = ratelimit.Per(60, time.Minute) // per IP: /programs listing
+	suggestLimit = ratelimit.Per(120, time.Minute)
+	scoreLimit   = ratelimit.Per(60, time.Minute) // per user
+	adminLimit   = ratelimit.Per(120, time.Minute)
+)
+
 func NewRouter(d Deps) *echo.Echo {
 	e := echo.New()
+	if d.TrustProxy {
+		e.IPExtractor = echo.ExtractIPFromXFFHeader()
+	} else {
+		e.IPExtractor = echo.ExtractIPDirect()
+	}
 
 	e.Use(echoMw.Logger())
 	e.Use(echoMw.Recover())
 	e.Use(echoMw.CORSWithConfig(echoMw.CORSConfig{
-		AllowOrigins: []string{"http://localhost:5173"},
-		AllowHeaders: []string{"Authorization", "Content-Type"},
-		AllowMethods: []string{"GET", "POST", "PUT", "PATCH", "DELETE", "OPTIONS"},
+		AllowOrigins:  []string{"http://localhost:5173"},
+		AllowHeaders:  []string{"Authorization", "Content-Type"},
+		AllowMethods:  []string{"GET", "POST", "PUT", "PATCH", "DELETE", "OPTIONS"},
+		ExposeHeaders: []string{"Retry-After", "X-RateLimit-Limit", "X-RateLimit-Remaining"},
 	}))
 
 	e.Use(middleware.CORSWithConfig(middleware.CORSConfig{
-		AllowOrigins: []string{"http://localhost:5173", "http://127.0.0.1:5173"},
-		AllowMethods: []string{echo.GET, echo.POST, echo.PUT, echo.DELETE, echo.OPTIONS},
-		AllowHeaders: []string{"Origin", "Content-Type", "Accept", "Authorization"},
+		AllowOrigins:  []string{"http://localhost:5173", "http://127.0.0.1:5173"},
+		AllowMethods:  []string{echo.GET, echo.POST, echo.PUT, echo.DELETE, echo.OPTIONS},
+		AllowHeaders:  []string{"Origin", "Content-Type", "Accept", "Authorization"},
+		ExposeHeaders: []string{"Retry-After", "X-RateLimit-Limit", "X-RateLimit-Remaining"},
 	}))
 
+	requireAuth := appMw.RequireAuth(d.JwtSecret, d.TokenVersions)
+	optionalAuth := appMw.OptionalAuth(d.JwtSecret, d.TokenVersions)
+	requireVerified := appMw.RequireVerified()
+	requireAdmin := appMw.RequireRole(appMw.RoleAdmin)
+	requireCounselor := appMw.RequireRole(appMw.RoleCounselor, appMw.RoleAdmin)
+
+	limit := func(name string, l ratelimit.Limit, key appMw.KeyFunc) echo.MiddlewareFunc {
+		if d.RateLimits == nil {
+			return func(next echo.HandlerFunc) echo.HandlerFunc { return next }
+		}
+		return appMw.RateLimit(d.RateLimits, name, l, key)
+	}
+	authRL := limit("auth", authLimit, appMw.ByIP)
+	searchRL := limit("search", searchLimit, appMw.ByIP)
+	scoreRL := limit("score", scoreLimit, appMw.ByUser)
+	adminRL := limit("admin", adminLimit, appMw.ByUser)
+
 	e.GET("/health", func(c echo.Context) error { return c.String(200, "ok") })
 
 	// auth (public)
-	e.POST("/auth/register", d.AuthHandler.Register)
-	e.POST("/auth/login", d.AuthHandler.Login)
+	e.POST("/auth/register", d.AuthHandler.Register, authRL)
+	e.POST("/auth/login", d.AuthHandler.Login, authRL)
+	e.POST("/auth/refresh", d.AuthHandler.Refresh, authRL)
+	e.POST("/auth/logout", d.AuthHandler.Logout, authRL)
+	e.POST("/auth/verify-email", d.AuthHandler.VerifyEmail, authRL)
+	e.POST("/auth/password/forgot", d.AuthHandler.ForgotPassword, authRL)
+	e.POST("/auth/password/reset", d.AuthHandler.ResetPassword, authRL)
 
 	// auth/me (protected)
-	e.GET("/auth/me", d.AuthHandler.Me, appMw.RequireAuth(d.JwtSecret))
+	e.GET("/auth/me", d.AuthHandler.Me, requireAuth)
+	e.POST("/auth/logout-all", d.AuthHandler.LogoutAll, requireAuth)
+	e.POST("/auth/verify-email/resend", d.AuthHandler.ResendVerification, requireAuth)
 
 	// programs (public)
-	e.GET("/programs", d.ProgramsHandler.List)
+	e.GET("/programs", d.ProgramsHandler.List, optionalAuth, searchRL)
+	e.GET("/programs/compare", d.ProgramsHandler.Compare, optionalAuth)
+	e.GET("/programs/:id", d.ProgramsHandler.Get, optionalAuth)
+	e.GET("/programs/:id/admission-stats", d.ProgramsHandler.AdmissionStats)
+	e.GET("/programs/:id/similar", d.ProgramsHandler.SimilarPrograms)
+	e.GET("/suggest", d.SearchHandler.Suggest, limit("suggest", suggestLimit, appMw.ByIP))
+
+	// reference data (public)
+	e.GET("/grading-systems", grading.Handler{}.List)
+	e.GET("/currencies", d.CurrenciesHandler.List)
+
+	// profile (protected; writes and scoring need a verified email)
+	e.GET("/profile/me", d.ProfileHandler.GetMe, requireAuth)
+	e.POST("/profile/me", d.ProfileHandler.UpsertMe, requireAuth, requireVerified)
+	e.POST("/score", d.ProfileHandler.ScoreProgram, requireAuth, requireVerified, scoreRL)
+	e.POST("/score/batch", d.ProfileHandler.ScoreBatch, requireAuth, requireVerified, scoreRL)
+	e.POST("/score/simulate", d.ProfileHandler.Simulate, requireAuth, requireVerified, scoreRL)
+
+	// counselor (own students, read-only)
+	e.GET("/counselor/students", d.ProfileHandler.Students, requireAuth, appMw.RequireRole(appMw.RoleCounselor))
+	e.GET("/counselor/students/:id/profile", d.ProfileHandler.StudentProfile, requireAuth, requireCounselor)
+
+	// admin
+	e.PUT("/admin/users/:id/role", d.AuthHandler.SetRole, requireAuth, requireAdmin)
+	e.PUT("/admin/counselors/:id/students/:student_id", d.AuthHandler.AssignStudent, requireAuth, requireAdmin)
+	e.DELETE("/admin/counselors/:id/students/:student_id", d.AuthHandler.UnassignStudent, requireAuth, requireAdmin)
 
-	// profile (protected)
-	e.GET("/profile/me", d.ProfileHandler.GetMe, appMw.RequireAuth(d.JwtSecret))
-	e.POST("/profile/me", d.ProfileHandler.UpsertMe, appMw.RequireAuth(d.JwtSecret))
-	e.POST("/score", d.ProfileHandler.ScoreProgram, appMw.RequireAuth(d.JwtSecret))
+	// admin catalog CRUD (audit_log, optimistic concurrency on updated_at)
+	for _, res := range admin.Resources {
+		g := e.Group("/admin/"+res, requireAuth, requireAdmin, adminRL)
+		g.GET("", d.AdminHandler.List(res))
+		g.POST("", d.AdminHandler.Create(res))
+		g.GET("/:id", d.AdminHandler.Get(res))
+		g.PATCH("/:id", d.AdminHandler.Update(res))
+		g.DELETE("/:id", d.AdminHandler.Delete(res))
+	}
 
 	// universities (public)
+	e.GET("/universities", d.UniversitiesHandler.List)
 	e.GET("/universities/:id", d.UniversitiesHandler.GetByID)
 
 	return e
@@ -0,0 +1,86 @@
+package i18n
+
+// catalog — key → тіл → мәтін. Кілттер scoring.Reason кодтарымен сәйкес ("reason.<code>").
+var catalog = map[string]map[Lang]string{
+	"reason.gpa_missing": {
+		KK: "GPA көрсетілмеген, баға дәлдігі төмен",
+		RU: "GPA не указан, точность оценки снижена",
+		EN: "GPA is missing, so the estimate is less accurate",
+	},
+	"reason.gpa_below_min": {
+		KK: "GPA талаптан төмен ({value} < {required})",
+		RU: "GPA ниже требований ({value} < {required})",
+		EN: "GPA is below the requirement ({value} < {required})",
+	},
+	"reason.language_missing": {
+		KK: "Тіл сертификаты жоқ (IELTS/TOEFL/DET/PTE/Cambridge)",
+		RU: "Нет языкового сертификата (IELTS/TOEFL/DET/PTE/Cambridge)",
+		EN: "No language certificate (IELTS/TOEFL/DET/PTE/Cambridge)",
+	},
+	"reason.language_below_min": {
+		KK: "{test} талаптан төмен ({value} < {required})",
+		RU: "{test} ниже требований ({value} < {required})",
+		EN: "{test} is below the requirement ({value} < {required})",
+	},
+	"reason.test_missing": {
+		KK: "{test} көрсетілмеген (кей универге міндетті)",
+		RU: "{test} не указан (обязателен в некоторых вузах)",
+		EN: "{test} not provided (required by some universities)",
+	},
+	"reason.test_required": {
+		KK: "{test} көрсетілмеген (бағдарламаға міндетті, мин. {required})",
+		RU: "{test} не указан (обязателен для программы, мин. {required})",
+		EN: "{test} not provided (required by the program, min. {required})",
+	},
+	"reason.test_below_min": {
+		KK: "{test} талаптан төмен ({value} < {required})",
+		RU: "{test} ниже требований ({value} < {required})",
+		EN: "{test} is below the requirement ({value} < {required})",
+	},
+
+	"recommendation.raise_gpa": {
+		KK: "GPA-ны {current}-ден {target}-ге көтеріңіз — ең төменгі талапқа жетеді (+{impact} ұпай)",
+		RU: "Повысьте GPA с {current} до {target}, чтобы пройти минимум (+{impact} баллов)",
+		EN: "Raise GPA from {current} to {target} to meet the minimum (+{impact} points)",
+	},
+	"recommendation.add_gpa": {
+		KK: "GPA-ны көрсетіңіз (мыс. {target} / 4.0) (+{impact} ұпай)",
+		RU: "Укажите GPA (например, {target} / 4.0) (+{impact} баллов)",
+		EN: "Add your GPA (e.g. {target} / 4.0) (+{impact} points)",
+	},
+	"recommendation.raise_language": {
+		KK: "{test} балын {current}-ден {target}-ге көтеріңіз — ең төменгі талапқа жетеді (+{impact} ұпай)",
+		RU: "Поднимите {test} с {current} до {target}, чтобы пройти минимум (+{impact} баллов)",
+		EN: "Raise {test} from {current} to {target} to meet the minimum (+{impact} points)",
+	},
+	"recommendation.raise_language_band": {
+		KK: "{test} балын {current}-ден {target}-ге көтеріңіз (+{impact} ұпай)",
+		RU: "Поднимите {test} с {current} до {target} (+{impact} баллов)",
+		EN: "Raise {test} from {current} to {target} (+{impact} points)",
+	},
+	"recommendation.add_language": {
+		KK: "{test} тапсырыңыз, кемінде {target} (+{impact} ұпай)",
+		RU: "Сдайте {test} минимум на {target} (+{impact} баллов)",
+		EN: "Take {test} and score at least {target} (+{impact} points)",
+	},
+	"recommendation.raise_test": {
+		KK: "{test} балын {current}-ден {target}-ге көтеріңіз — ең төменгі талапқа жетеді (+{impact} ұпай)",
+		RU: "Поднимите {test} с {current} до {target}, чтобы пройти минимум (+{impact} баллов)",
+		EN: "Raise {test} from {current} to {target} to meet the minimum (+{impact} points)",
+	},
+	"recommendation.add_test": {
+		KK: "{test} тапсырыңыз, кемінде {target} (+{impact} ұпай)",
+		RU: "Сдайте {test} минимум на {target} (+{impact} баллов)",
+		EN: "Add {test} with at least {target} to reach the target band (+{impact} points)",
+	},
+	"recommendation.add_awards": {
+		KK: "Марапаттарыңызды (олимпиада, конкурс) қосыңыз (+{impact} ұпай)",
+		RU: "Добавьте награды (олимпиады, конкурсы) (+{impact} баллов)",
+		EN: "Add your awards (olympiads, competitions) (+{impact} points)",
+	},
+	"recommendation.add_achievements": {
+		KK: "Жетістіктеріңізді қысқаша сипаттаңыз (+{impact} ұпай)",
+		RU: "Кратко опишите свои достижения (+{impact} баллов)",
+		EN: "Describe your achievements briefly (+{impact} points)",
+	},
+}
@@ -0,0 +1,84 @@
+package i18n
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+)
+
+type Lang string
+
+const (
+	KK Lang = "kk"
+	RU Lang = "ru"
+	EN Lang = "en"
+
+	Default = KK
+)
+
+func Supported(code string) bool {
+	switch Lang(code) {
+	case KK, RU, EN:
+		return true
+	}
+	return false
+}
+
+// Resolve picks the response language: the user's saved preference first,
+// then the best supported Accept-Language entry, then Default.
+func Resolve(preference *string, acceptLanguage string) Lang {
+	if preference != nil && Supported(*preference) {
+		return Lang(*preference)
+	}
+	return FromAcceptLanguage(acceptLanguage)
+}
+
+// FromAcceptLanguage parses "ru-RU,ru;q=0.9,en;q=0.8" style headers.
+func FromAcceptLanguage(header string) Lang {
+	type entry struct {
+		lang string
+		q    float64
+	}
+	var entries []entry
+	for _, part := range strings.Split(header, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		tag, q := part, 1.0
+		if i := strings.Index(part, ";"); i >= 0 {
+			tag = strings.TrimSpace(part[:i])
+			if v, ok := strings.CutPrefix(strings.TrimSpace(part[i+1:]), "q="); ok {
+				if f, err := strconv.ParseFloat(v, 64); err == nil {
+					q = f
+				}
+			}
+		}
+		base, _, _ := strings.Cut(strings.ToLower(tag), "-")
+		entries = append(entries, entry{base, q})
+	}
+	sort.SliceStable(entries, func(i, j int) bool { return entries[i].q > entries[j].q })
+	for _, e := range entries {
+		if e.q > 0 && Supported(e.lang) {
+			return Lang(e.lang)
+		}
+	}
+	return Default
+}
+
+// T renders the message for key, substituting {name} placeholders.
+// Falls back to Default, then to the key itself.
+func T(lang Lang, key string, args map[string]string) string {
+	msgs, ok := catalog[key]
+	if !ok {
+		return key
+	}
+	msg, ok := msgs[lang]
+	if !ok {
+		msg = msgs[Default]
+	}
+	for k, v := range args {
+		msg = strings.ReplaceAll(msg, "{"+k+"}", v)
+	}
+	return msg
+}
@@ -0,0 +1,189 @@
+// Package keyset implements opaque cursor paging over a list of sort keys,
+// shared by GET /programs and GET /universities.
+package keyset
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"strings"
+)
+
+var ErrBadCursor = errors.New("invalid cursor")
+
+// NoRank — NULL рейтингтер соңына түсуі үшін (NULLS LAST орнына).
+const NoRank = "2147483647"
+
+// Key — keyset өрнегі. Барлық кілттер ASC және NULL-сыз жазылады
+// (DESC кілттер терісімен), сондықтан (k1..kn) > (v1..vn) жолдық салыстыруы жеткілікті.
+type Key struct {
+	Expr string
+	Kind string // int | float | text | uuid
+}
+
+// Cursor — клиентке opaque base64 токен ретінде беріледі.
+type Cursor struct {
+	Sort   string `json:"s"`
+	Filter string `json:"f"` // Hash; басқа фильтрдің токені қабылданбайды
+	Keys   []any  `json:"k"`
+	Back   bool   `json:"b,omitempty"` // алдыңғы бет
+}
+
+func (c Cursor) Encode() string {
+	b, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+// Decode parses a token and converts its keys to the SQL types of keys.
+func Decode(token, sort, fhash string, keys []Key) (Cursor, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return Cursor{}, ErrBadCursor
+	}
+	dec := json.NewDecoder(strings.NewReader(string(raw)))
+	dec.UseNumber()
+	var c Cursor
+	if err := dec.Decode(&c); err != nil {
+		return Cursor{}, ErrBadCursor
+	}
+	if c.Sort != sort || c.Filter != fhash || len(c.Keys) != len(keys) {
+		return Cursor{}, ErrBadCursor
+	}
+
+	for i, k := range keys {
+		switch k.Kind {
+		case "int", "float":
+			n, ok := c.Keys[i].(json.Number)
+			if !ok {
+				return Cursor{}, ErrBadCursor
+			}
+			if k.Kind == "int" {
+				v, err := n.Int64()
+				if err != nil {
+					return Cursor{}, ErrBadCursor
+				}
+				c.Keys[i] = v
+			} else {
+				v, err := n.Float64()
+				if err != nil {
+					return Cursor{}, ErrBadCursor
+				}
+				c.Keys[i] = v
+			}
+		default:
+			if _, ok := c.Keys[i].(string); !ok {
+				return Cursor{}, ErrBadCursor
+			}
+		}
+	}
+	return c, nil
+}
+
+// Dest allocates scan targets for the key columns of one row.
+func Dest(keys []Key) []any {
+	out := make([]any, len(keys))
+	for i, k := range keys {
+		switch k.Kind {
+		case "int":
+			out[i] = new(int64)
+		case "float":
+			out[i] = new(float64)
+		default:
+			out[i] = new(string)
+		}
+	}
+	return out
+}
+
+// Values dereferences the targets filled by Dest.
+func Values(dest []any) []any {
+	out := make([]any, len(dest))
+	for i, d := range dest {
+		switch v := d.(type) {
+		case *int64:
+			out[i] = *v
+		case *float64:
+			out[i] = *v
+		case *string:
+			out[i] = *v
+		}
+	}
+	return out
+}
+
+// Hash fingerprints list params with paging fields already cleared, so a
+// cursor cannot be replayed against a different search.
+func Hash(params any) string {
+	b, _ := json.Marshal(params)
+	h := fnv.New32a()
+	h.Write(b)
+	return fmt.Sprintf("%08x", h.Sum32())
+}
+
+// Where renders "(k1,..,kn) > ($a::int8,..)" for the cursor keys.
+func Where(keys []Key, back bool, firstArg int) string {
+	cols := make([]string, len(keys))
+	vals := make([]string, len(keys))
+	for i, k := range keys {
+		cols[i] = k.Expr
+		typ := map[string]string{"int": "int8", "float": "float8", "text": "text", "uuid": "uuid"}[k.Kind]
+		vals[i] = fmt.Sprintf("$%d::%s", firstArg+i, typ)
+	}
+	op := ">"
+	if back {
+		op = "<"
+	}
+	return "(" + strings.Join(cols, ", ") + ") " + op + " (" + strings.Join(vals, ", ") + ")"
+}
+
+// Order renders the ORDER BY list; back reverses it to walk to the previous page.
+func Order(keys []Key, back bool) string {
+	dir := " ASC"
+	if back {
+		dir = " DESC"
+	}
+	parts := make([]string, len(keys))
+	for i, k := range keys {
+		parts[i] = k.Expr + dir
+	}
+	return strings.Join(parts, ", ")
+}
+
+// Exprs lists the key expressions for the SELECT list.
+func Exprs(keys []Key) []string {
+	out := make([]string, len(keys))
+	for i, k := range keys {
+		out[i] = k.Expr
+	}
+	return out
+}
+
+// Links builds next/prev tokens for a fetched page. rowKeys are in display
+// order (already reversed when cur.Back); more means the query returned the
+// extra row; moved is true when the page is not the first one.
+func Links(cur Cursor, sort, fhash string, rowKeys [][]any, more, moved bool) (next, prev string) {
+	if len(rowKeys) == 0 {
+		return "", ""
+	}
+	// келесі бет: алға жүргенде артық жол болса; артқа жүргенде әрқашан бар
+	hasNext := cur.Back || more
+	hasPrev := more
+	if !cur.Back {
+		hasPrev = moved
+	}
+	if hasNext {
+		next = Cursor{Sort: sort, Filter: fhash, Keys: rowKeys[len(rowKeys)-1]}.Encode()
+	}
+	if hasPrev {
+		prev = Cursor{Sort: sort, Filter: fhash, Keys: rowKeys[0], Back: true}.Encode()
+	}
+	return next, prev
+}
+
+func Reverse[T any](s []T) {
+	for i, j := 0, len(s)-1; i < j; i, j = i+1, j-1 {
+		s[i], s[j] = s[j], s[i]
+	}
+}
@@ -0,0 +1,160 @@
+package keyset
+
+import (
+	"encoding/base64"
+	"reflect"
+	"testing"
+)
+
+var testKeys = []Key{
+	{Expr: "COALESCE(universities.qs_rank, " + NoRank + ")", Kind: "int"},
+	{Expr: "(-rank)::float8", Kind: "float"},
+	{Expr: "programs.title", Kind: "text"},
+	{Expr: "programs.id", Kind: "uuid"},
+}
+
+func TestCursorRoundTrip(t *testing.T) {
+	tests := []Cursor{
+		{Sort: "default", Filter: "abcd1234", Keys: []any{int64(12), -0.25, "Computer Science", "0b6f6c39-5a4e-4b59-8f1e-1a2b3c4d5e6f"}},
+		{Sort: "default", Filter: "abcd1234", Keys: []any{int64(2147483647), 1e300, "", "00000000-0000-0000-0000-000000000000"}, Back: true},
+	}
+	for _, c := range tests {
+		got, err := Decode(c.Encode(), c.Sort, c.Filter, testKeys)
+		if err != nil {
+			t.Errorf("Decode(%+v): %v", c, err)
+			continue
+		}
+		if !reflect.DeepEqual(got, c) {
+			t.Errorf("round trip = %+v, want %+v", got, c)
+		}
+	}
+}
+
+func TestDecodeRejects(t *testing.T) {
+	valid := Cursor{Sort: "default", Filter: "abcd1234", Keys: []any{int64(1), 0.5, "a", "b"}}
+	raw := func(s string) string { return base64.RawURLEncoding.EncodeToString([]byte(s)) }
+
+	tests := []struct {
+		name  string
+		token string
+		sort  string
+		fhash string
+	}{
+		{"not base64", "%%%", "default", "abcd1234"},
+		{"not json", raw("{"), "default", "abcd1234"},
+		{"other sort", valid.Encode(), "qs", "abcd1234"},
+		{"other filter", valid.Encode(), "default", "ffffffff"},
+		{"too few keys", Cursor{Sort: "default", Filter: "abcd1234", Keys: []any{int64(1)}}.Encode(), "default", "abcd1234"},
+		{"string for int", raw(`{"s":"default","f":"abcd1234","k":["1",0.5,"a","b"]}`), "default", "abcd1234"},
+		{"fraction for int", raw(`{"s":"default","f":"abcd1234","k":[1.5,0.5,"a","b"]}`), "default", "abcd1234"},
+		{"number for text", raw(`{"s":"default","f":"abcd1234","k":[1,0.5,7,"b"]}`), "default", "abcd1234"},
+		{"null key", raw(`{"s":"default","f":"abcd1234","k":[1,null,"a","b"]}`), "default", "abcd1234"},
+	}
+	for _, tt := range tests {
+		if _, err := Decode(tt.token, tt.sort, tt.fhash, testKeys); err != ErrBadCursor {
+			t.Errorf("%s: err = %v, want ErrBadCursor", tt.name, err)
+		}
+	}
+}
+
+func TestWhere(t *testing.T) {
+	keys := []Key{{Expr: "a", Kind: "int"}, {Expr: "b", Kind: "float"}, {Expr: "c", Kind: "text"}, {Expr: "d", Kind: "uuid"}}
+	tests := []struct {
+		back     bool
+		firstArg int
+		want     string
+	}{
+		{false, 1, "(a, b, c, d) > ($1::int8, $2::float8, $3::text, $4::uuid)"},
+		{true, 5, "(a, b, c, d) < ($5::int8, $6::float8, $7::text, $8::uuid)"},
+	}
+	for _, tt := range tests {
+		if got := Where(keys, tt.back, tt.firstArg); got != tt.want {
+			t.Errorf("Where(back=%v, %d) = %q, want %q", tt.back, tt.firstArg, got, tt.want)
+		}
+	}
+}
+
+func TestOrder(t *testing.T) {
+	keys := []Key{{Expr: "a", Kind: "int"}, {Expr: "b", Kind: "uuid"}}
+	if got := Order(keys, false); got != "a ASC, b ASC" {
+		t.Errorf("Order forward = %q", got)
+	}
+	if got := Order(keys, true); got != "a DESC, b DESC" {
+		t.Errorf("Order back = %q", got)
+	}
+}
+
+func TestDestValues(t *testing.T) {
+	dest := Dest(testKeys)
+	*dest[0].(*int64) = 7
+	*dest[1].(*float64) = 1.5
+	*dest[2].(*string) = "x"
+	*dest[3].(*string) = "id"
+	if got := Values(dest); !reflect.DeepEqual(got, []any{int64(7), 1.5, "x", "id"}) {
+		t.Errorf("Values = %v", got)
+	}
+}
+
+func TestLinks(t *testing.T) {
+	rows := [][]any{{int64(1)}, {int64(2)}}
+	tests := []struct {
+		name             string
+		back             bool
+		more, moved      bool
+		rows             [][]any
+		hasNext, hasPrev bool
+	}{
+		{"first page, more rows", false, true, false, rows, true, false},
+		{"first and only page", false, false, false, rows, false, false},
+		{"middle page", false, true, true, rows, true, true},
+		{"last page", false, false, true, rows, false, true},
+		{"back, more before", true, true, true, rows, true, true},
+		{"back to the first page", true, false, true, rows, true, false},
+		{"empty page", false, true, true, nil, false, false},
+	}
+	for _, tt := range tests {
+		next, prev := Links(Cursor{Back: tt.back}, "default", "abcd1234", tt.rows, tt.more, tt.moved)
+		if (next != "") != tt.hasNext || (prev != "") != tt.hasPrev {
+			t.Errorf("%s: next=%q prev=%q", tt.name, next, prev)
+			continue
+		}
+		if next != "" {
+			c, err := Decode(next, "default", "abcd1234", []Key{{Kind: "int"}})
+			if err != nil || c.Back || c.Keys[0] != int64(2) {
+				t.Errorf("%s: next cursor = %+v, %v", tt.name, c, err)
+			}
+		}
+		if prev != "" {
+			c, err := Decode(prev, "default", "abcd1234", []Key{{Kind: "int"}})
+			if err != nil || !c.Back || c.Keys[0] != int64(1) {
+				t.Errorf("%s: prev cursor = %+v, %v", tt.name, c, err)
+			}
+		}
+	}
+}
+
+func TestHash(t *testing.T) {
+	type params struct {
+		Q     string
+		Sort  string
+		Limit int
+	}
+	a := Hash(params{Q: "math", Sort: "qs"})
+	if a != Hash(params{Q: "math", Sort: "qs"}) {
+		t.Error("Hash is not stable")
+	}
+	if a == Hash(params{Q: "maths", Sort: "qs"}) {
+		t.Error("Hash ignores filter changes")
+	}
+	if len(a) != 8 {
+		t.Errorf("Hash = %q, want 8 hex chars", a)
+	}
+}
+
+func TestReverse(t *testing.T) {
+	s := []int{1, 2, 3, 4}
+	Reverse(s)
+	if !reflect.DeepEqual(s, []int{4, 3, 2, 1}) {
+		t.Errorf("Reverse = %v", s)
+	}
+}
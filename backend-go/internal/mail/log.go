@@ -0,0 +1,44 @@
+package mail
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// LogMailer does not send anything: messages are logged, and when Dir is set
+// also written there as .eml files (tests and local development read links from them).
+type LogMailer struct {
+	Dir string
+}
+
+func (m LogMailer) Send(ctx context.Context, msg Message) error {
+	if m.Dir == "" {
+		log.Printf("mail to=%s subject=%q\n%s", msg.To, msg.Subject, msg.Body)
+		return nil
+	}
+	if err := os.MkdirAll(m.Dir, 0o755); err != nil {
+		return err
+	}
+	name := fmt.Sprintf("%s_%s.eml", time.Now().UTC().Format("20060102T150405.000000000"), safeName(msg.To))
+	path := filepath.Join(m.Dir, name)
+	if err := os.WriteFile(path, render("noreply@localhost", msg), 0o644); err != nil {
+		return err
+	}
+	log.Printf("mail to=%s subject=%q written to %s", msg.To, msg.Subject, path)
+	return nil
+}
+
+func safeName(s string) string {
+	return strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9', r == '.', r == '-':
+			return r
+		}
+		return '_'
+	}, s)
+}
@@ -0,0 +1,44 @@
+package mail
+
+import "context"
+
+// Message is a plain-text email.
+type Message struct {
+	To      string
+	Subject string
+	Body    string
+}
+
+// Mailer sends transactional emails (verification, password reset).
+type Mailer interface {
+	Send(ctx context.Context, m Message) error
+}
+
+// Config — SMTP_* / MAIL_* env мәндері.
+type Config struct {
+	SMTPHost     string
+	SMTPPort     string
+	SMTPUser     string
+	SMTPPassword string
+	From         string
+	Dir          string // SMTP жоқ болса хаттар осы папкаға жазылады
+}
+
+// New returns an SMTP mailer when SMTPHost is set, otherwise a LogMailer
+// (local development: links are printed to the log / written to Dir).
+func New(cfg Config) Mailer {
+	if cfg.SMTPHost == "" {
+		return LogMailer{Dir: cfg.Dir}
+	}
+	port := cfg.SMTPPort
+	if port == "" {
+		port = "587"
+	}
+	return SMTPMailer{
+		Addr:     cfg.SMTPHost + ":" + port,
+		Host:     cfg.SMTPHost,
+		Username: cfg.SMTPUser,
+		Password: cfg.SMTPPassword,
+		From:     cfg.From,
+	}
+}
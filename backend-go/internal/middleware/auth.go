@@ -1,6 +1,8 @@
 package middleware
 
 import (
+	"context"
+	"errors"
 	"net/http"
 	"strings"
 
@@ -8,12 +10,34 @@ import (
 	"github.com/labstack/echo/v4"
 )
 
+// Roles (users.role).
+const (
+	RoleStudent   = "student"
+	RoleCounselor = "counselor"
+	RoleAdmin     = "admin"
+)
+
 type CtxUser struct {
-	ID    string
-	Email string
+	ID            string
+	Email         string
+	EmailVerified bool
+	Role          string
+}
+
+// ValidRole reports whether r is one of the users.role values.
+func ValidRole(r string) bool {
+	return r == RoleStudent || r == RoleCounselor || r == RoleAdmin
 }
 
-func RequireAuth(jwtSecret string) echo.MiddlewareFunc {
+// TokenVersions returns users.token_version; access tokens carrying an older
+// "ver" claim were revoked by logout-all. nil disables the check.
+type TokenVersions interface {
+	TokenVersion(ctx context.Context, userID string) (int, error)
+}
+
+var errTokenRevoked = errors.New("token revoked")
+
+func RequireAuth(jwtSecret string, versions TokenVersions) echo.MiddlewareFunc {
 	return func(next echo.HandlerFunc) echo.HandlerFunc {
 		return func(c echo.Context) error {
 
@@ -24,39 +48,119 @@ func RequireAuth(jwtSecret string) echo.MiddlewareFunc {
 				})
 			}
 
-			tokenStr := strings.TrimPrefix(authHeader, "Bearer ")
-
-			token, err := jwt.Parse(tokenStr, func(t *jwt.Token) (any, error) {
-				return []byte(jwtSecret), nil
-			})
-			if err != nil || !token.Valid {
+			u, err := authenticate(c.Request().Context(), jwtSecret, versions, strings.TrimPrefix(authHeader, "Bearer "))
+			if err != nil {
 				return c.JSON(http.StatusUnauthorized, map[string]string{
-					"error": "invalid token",
+					"error": err.Error(),
 				})
 			}
 
-			claims, ok := token.Claims.(jwt.MapClaims)
-			if !ok {
-				return c.JSON(http.StatusUnauthorized, map[string]string{
-					"error": "invalid token claims",
-				})
-			}
+			c.Set("user", u)
 
-			sub, _ := claims["sub"].(string)
-			email, _ := claims["email"].(string)
+			return next(c)
+		}
+	}
+}
 
-			if sub == "" || email == "" {
-				return c.JSON(http.StatusUnauthorized, map[string]string{
-					"error": "invalid token payload",
+// OptionalAuth sets "user" when a valid Bearer token is present and lets
+// anonymous (or invalid-token) requests through unchanged.
+func OptionalAuth(jwtSecret string, versions TokenVersions) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			authHeader := c.Request().Header.Get("Authorization")
+			if strings.HasPrefix(authHeader, "Bearer ") {
+				if u, err := authenticate(c.Request().Context(), jwtSecret, versions, strings.TrimPrefix(authHeader, "Bearer ")); err == nil {
+					c.Set("user", u)
+				}
+			}
+			return next(c)
+		}
+	}
+}
+
+// RequireVerified must follow RequireAuth: accounts that have not confirmed
+// their email get 403 until a token issued after verification is used.
+func RequireVerified() echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			if u, ok := UserFrom(c); !ok || !u.EmailVerified {
+				return c.JSON(http.StatusForbidden, map[string]string{
+					"error": "email not verified",
 				})
 			}
+			return next(c)
+		}
+	}
+}
 
-			c.Set("user", CtxUser{
-				ID:    sub,
-				Email: email,
+// RequireRole must follow RequireAuth and lets through only the listed roles.
+func RequireRole(roles ...string) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			u, ok := UserFrom(c)
+			if ok {
+				for _, r := range roles {
+					if u.Role == r {
+						return next(c)
+					}
+				}
+			}
+			return c.JSON(http.StatusForbidden, map[string]string{
+				"error": "forbidden",
 			})
-
-			return next(c)
 		}
 	}
 }
+
+// UserFrom returns the authenticated user, if any.
+func UserFrom(c echo.Context) (CtxUser, bool) {
+	u, ok := c.Get("user").(CtxUser)
+	return u, ok
+}
+
+func authenticate(ctx context.Context, jwtSecret string, versions TokenVersions, tokenStr string) (CtxUser, error) {
+	u, ver, err := parseToken(jwtSecret, tokenStr)
+	if err != nil || versions == nil {
+		return u, err
+	}
+	current, err := versions.TokenVersion(ctx, u.ID)
+	if err != nil || ver != current {
+		return CtxUser{}, errTokenRevoked
+	}
+	return u, nil
+}
+
+// parseToken returns the user and the "ver" claim (0 for tokens issued before it existed).
+func parseToken(jwtSecret, tokenStr string) (CtxUser, int, error) {
+	token, err := jwt.Parse(tokenStr, func(t *jwt.Token) (any, error) {
+		return []byte(jwtSecret), nil
+	})
+	if err != nil || !token.Valid {
+		return CtxUser{}, 0, errors.New("invalid token")
+	}
+
+	claims, ok := token.Claims.(jwt.MapClaims)
+	if !ok {
+		return CtxUser{}, 0, errors.New("invalid token claims")
+	}
+
+	sub, _ := claims["sub"].(string)
+	email, _ := claims["email"].(string)
+
+	if sub == "" || email == "" {
+		return CtxUser{}, 0, errors.New("invalid token payload")
+	}
+	ver, _ := claims["ver"].(float64)
+	verified, _ := claims["email_verified"].(bool)
+	role, _ := claims["role"].(string)
+	if role == "" {
+		role = RoleStudent
+	}
+
+	return CtxUser{
+		ID:            sub,
+		Email:         email,
+		EmailVerified: verified,
+		Role:          role,
+	}, int(ver), nil
+}
@@ -0,0 +1,62 @@
+package middleware
+
+import (
+	"log"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/labstack/echo/v4"
+
+	"unichance-backend-go/internal/ratelimit"
+)
+
+// KeyFunc returns the rate-limit key of a request ("" skips limiting).
+type KeyFunc func(c echo.Context) string
+
+// ByIP keys on the client IP (see Echo IPExtractor for proxy handling).
+func ByIP(c echo.Context) string { return "ip:" + c.RealIP() }
+
+// ByUser keys on the authenticated user, falling back to IP; use after RequireAuth/OptionalAuth.
+func ByUser(c echo.Context) string {
+	if u, ok := UserFrom(c); ok {
+		return "user:" + u.ID
+	}
+	return ByIP(c)
+}
+
+// RateLimit applies a token-bucket limit per key and route group name. Store
+// errors are logged and the request goes through (fail open).
+func RateLimit(store ratelimit.Store, name string, limit ratelimit.Limit, key KeyFunc) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			k := key(c)
+			if k == "" {
+				return next(c)
+			}
+			res, err := store.Take(c.Request().Context(), name+":"+k, limit)
+			if err != nil {
+				log.Printf("ratelimit %s: %v", name, err)
+				return next(c)
+			}
+
+			h := c.Response().Header()
+			h.Set("X-RateLimit-Limit", strconv.Itoa(limit.Burst))
+			h.Set("X-RateLimit-Remaining", strconv.Itoa(res.Remaining))
+			if !res.Allowed {
+				return TooManyRequests(c, res.RetryAfter)
+			}
+			return next(c)
+		}
+	}
+}
+
+// TooManyRequests writes 429 with Retry-After (seconds, rounded up).
+func TooManyRequests(c echo.Context, wait time.Duration) error {
+	retry := ratelimit.RetrySeconds(wait)
+	c.Response().Header().Set("Retry-After", strconv.Itoa(retry))
+	return c.JSON(http.StatusTooManyRequests, map[string]any{
+		"error":       "too many requests",
+		"retry_after": retry,
+	})
+}
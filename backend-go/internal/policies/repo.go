@@ -0,0 +1,77 @@
+package policies
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"unichance-backend-go/internal/scoring"
+)
+
+var ErrNotFound = errors.New("scoring policy not found")
+
+type Repo struct {
+	DB *pgxpool.Pool
+}
+
+// Active returns the active version of the named policy.
+func (r Repo) Active(ctx context.Context, name string) (scoring.Policy, error) {
+	return r.scan(ctx, `
+    SELECT name, version, config
+    FROM scoring_policies
+    WHERE name = $1 AND is_active
+    ORDER BY version DESC
+    LIMIT 1
+  `, name)
+}
+
+func (r Repo) Get(ctx context.Context, name string, version int) (scoring.Policy, error) {
+	return r.scan(ctx, `
+    SELECT name, version, config
+    FROM scoring_policies
+    WHERE name = $1 AND version = $2
+  `, name, version)
+}
+
+func (r Repo) scan(ctx context.Context, q string, args ...any) (scoring.Policy, error) {
+	var name string
+	var version int
+	var config []byte
+	err := r.DB.QueryRow(ctx, q, args...).Scan(&name, &version, &config)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return scoring.Policy{}, ErrNotFound
+		}
+		return scoring.Policy{}, err
+	}
+
+	pol := scoring.DefaultPolicy()
+	if err := json.Unmarshal(config, &pol); err != nil {
+		return scoring.Policy{}, err
+	}
+	// name/version бағандары config ішіндегіден басым
+	pol.Name, pol.Version = name, version
+	if err := pol.Validate(); err != nil {
+		return scoring.Policy{}, err
+	}
+	return pol, nil
+}
+
+// Load resolves the policy the API scores with: a file wins over the
+// database, and DefaultPolicy is used when neither has it.
+func Load(ctx context.Context, r Repo, file, name string) (scoring.Policy, error) {
+	if file != "" {
+		return scoring.LoadPolicyFile(file)
+	}
+	if name == "" {
+		name = scoring.DefaultPolicy().Name
+	}
+	pol, err := r.Active(ctx, name)
+	if errors.Is(err, ErrNotFound) {
+		return scoring.DefaultPolicy(), nil
+	}
+	return pol, err
+}
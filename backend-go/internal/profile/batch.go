@@ -0,0 +1,115 @@
+package profile
+
+import (
+  "context"
+  "errors"
+  "net/http"
+
+  "github.com/jackc/pgx/v5"
+  "github.com/labstack/echo/v4"
+
+  "unichance-backend-go/internal/i18n"
+  "unichance-backend-go/internal/middleware"
+  "unichance-backend-go/internal/programs"
+  "unichance-backend-go/internal/scoring"
+)
+
+// MaxBatchPrograms — бір batch сұрауындағы бағдарламалар шегі.
+const MaxBatchPrograms = 50
+
+type batchReq struct {
+  ProgramIDs []string `json:"program_ids"`
+  Filter *programs.ListParams `json:"filter"` // program_ids орнына: іздеу бетінің сүзгісі
+  Mode string `json:"mode"`
+  IncludeLiving bool `json:"include_living"`
+}
+
+// ScoreBatch scores a whole results page or shortlist in one call.
+// Unlike /score it does not write to the scores history.
+func (h Handler) ScoreBatch(c echo.Context) error {
+  u := c.Get("user").(middleware.CtxUser)
+  ctx := c.Request().Context()
+
+  var req batchReq
+  if err := c.Bind(&req); err != nil {
+    return c.JSON(http.StatusBadRequest, map[string]string{"error":"bad body"})
+  }
+
+  ids := req.ProgramIDs
+  if len(ids) == 0 && req.Filter != nil {
+    params := *req.Filter
+    if params.Limit <= 0 || params.Limit > MaxBatchPrograms { params.Limit = MaxBatchPrograms }
+    params.SkipTotal = true
+    res, err := h.Programs.List(ctx, params)
+    if err != nil { return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()}) }
+    for _, it := range res.Items { ids = append(ids, it.ID) }
+  }
+  if len(ids) == 0 && req.Filter == nil {
+    return c.JSON(http.StatusBadRequest, map[string]string{"error":"program_ids or filter required"})
+  }
+  if len(ids) > MaxBatchPrograms {
+    return c.JSON(http.StatusBadRequest, map[string]string{"error":"too many program_ids"})
+  }
+
+  prof, err := h.Repo.GetMyProfile(ctx, u.ID)
+  if err != nil { return c.JSON(http.StatusBadRequest, map[string]string{"error":"profile not found"}) }
+
+  results, err := h.scoreMany(ctx, prof, ids, scoreOpts{
+    Probability: req.Mode == ModeProbability,
+    IncludeLiving: req.IncludeLiving,
+  })
+  if err != nil { return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()}) }
+
+  lang := i18n.Resolve(prof.Locale, c.Request().Header.Get("Accept-Language"))
+  for i := range results { results[i] = results[i].localize(lang) }
+
+  // жоқ/өшірілген бағдарламалар ұпайсыз қайтарылады
+  return c.JSON(http.StatusOK, map[string]any{"items": results, "missing": missingIDs(ids, results)})
+}
+
+// ScorePrograms implements programs.Scorer for score embedding in GET /programs.
+// A user without a profile gets no scores rather than an error.
+func (h Handler) ScorePrograms(ctx context.Context, userID string, programIDs []string) (map[string]programs.CardScore, error) {
+  _, results, err := h.scoreForUser(ctx, userID, programIDs)
+  if err != nil || results == nil { return nil, err }
+
+  out := make(map[string]programs.CardScore, len(results))
+  for _, r := range results { out[r.ProgramID] = r.cardScore() }
+  return out, nil
+}
+
+// ScoreDetails implements programs.Scorer for GET /programs/compare: the card
+// score plus warning/blocker reasons in the user's language.
+func (h Handler) ScoreDetails(ctx context.Context, userID, acceptLanguage string, programIDs []string) (map[string]programs.ScoreDetail, error) {
+  prof, results, err := h.scoreForUser(ctx, userID, programIDs)
+  if err != nil || results == nil { return nil, err }
+
+  lang := i18n.Resolve(prof.Locale, acceptLanguage)
+  out := make(map[string]programs.ScoreDetail, len(results))
+  for _, r := range results {
+    gaps := []scoring.Reason{}
+    for _, reason := range r.Reasons {
+      if reason.Severity != scoring.SeverityInfo { gaps = append(gaps, reason) }
+    }
+    out[r.ProgramID] = programs.ScoreDetail{CardScore: r.cardScore(), Gaps: localizeReasons(lang, gaps)}
+  }
+  return out, nil
+}
+
+// scoreForUser scores programs in probability mode; nil results when the
+// user has no profile yet.
+func (h Handler) scoreForUser(ctx context.Context, userID string, programIDs []string) (Profile, []ScoreResult, error) {
+  prof, err := h.Repo.GetMyProfile(ctx, userID)
+  if err != nil {
+    if errors.Is(err, pgx.ErrNoRows) { return Profile{}, nil, nil }
+    return Profile{}, nil, err
+  }
+  results, err := h.scoreMany(ctx, prof, programIDs, scoreOpts{Probability: true, SkipRecommend: true})
+  return prof, results, err
+}
+
+func (r ScoreResult) cardScore() programs.CardScore {
+  cs := programs.CardScore{Score: r.Score, Probability: r.Probability, Category: string(r.Category)}
+  if r.Affordability != nil { cs.Affordability = string(r.Affordability.Status) }
+  return cs
+}
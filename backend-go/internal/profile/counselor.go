@@ -0,0 +1,79 @@
+package profile
+
+import (
+  "context"
+  "errors"
+  "net/http"
+  "time"
+
+  "github.com/jackc/pgx/v5"
+  "github.com/labstack/echo/v4"
+
+  "unichance-backend-go/internal/middleware"
+)
+
+// Student — кеңесшіге бекітілген студент.
+type Student struct {
+  UserID string `json:"user_id"`
+  Email string `json:"email"`
+  HasProfile bool `json:"has_profile"`
+  AssignedAt time.Time `json:"assigned_at"`
+}
+
+func (r Repo) Students(ctx context.Context, counselorID string) ([]Student, error) {
+  rows, err := r.DB.Query(ctx, `
+    SELECT u.id, u.email, EXISTS (SELECT 1 FROM profiles p WHERE p.user_id = u.id), cs.created_at
+    FROM counselor_students cs
+    JOIN users u ON u.id = cs.student_id
+    WHERE cs.counselor_id = $1
+    ORDER BY u.email
+  `, counselorID)
+  if err != nil { return nil, err }
+  defer rows.Close()
+
+  out := []Student{}
+  for rows.Next() {
+    var s Student
+    if err := rows.Scan(&s.UserID, &s.Email, &s.HasProfile, &s.AssignedAt); err != nil { return nil, err }
+    out = append(out, s)
+  }
+  return out, rows.Err()
+}
+
+// CanView: admin — кез келген профиль, counselor — тек өз студенттері.
+func (r Repo) CanView(ctx context.Context, viewer middleware.CtxUser, studentID string) (bool, error) {
+  switch viewer.Role {
+  case middleware.RoleAdmin:
+    return true, nil
+  case middleware.RoleCounselor:
+    var ok bool
+    err := r.DB.QueryRow(ctx,
+      `SELECT EXISTS (SELECT 1 FROM counselor_students WHERE counselor_id=$1 AND student_id=$2)`,
+      viewer.ID, studentID,
+    ).Scan(&ok)
+    return ok, err
+  }
+  return false, nil
+}
+
+// Students — GET /counselor/students (counselor)
+func (h Handler) Students(c echo.Context) error {
+  u := c.Get("user").(middleware.CtxUser)
+  items, err := h.Repo.Students(c.Request().Context(), u.ID)
+  if err != nil { return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()}) }
+  return c.JSON(http.StatusOK, map[string]any{"items": items})
+}
+
+// StudentProfile — GET /counselor/students/:id/profile (counselor, admin); read-only.
+func (h Handler) StudentProfile(c echo.Context) error {
+  u := c.Get("user").(middleware.CtxUser)
+  studentID := c.Param("id")
+  ok, err := h.Repo.CanView(c.Request().Context(), u, studentID)
+  if err != nil { return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()}) }
+  if !ok { return c.JSON(http.StatusForbidden, map[string]string{"error": "not your student"}) }
+
+  p, err := h.Repo.GetMyProfile(c.Request().Context(), studentID)
+  if errors.Is(err, pgx.ErrNoRows) { return c.JSON(http.StatusOK, map[string]any{"profile": nil}) }
+  if err != nil { return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()}) }
+  return c.JSON(http.StatusOK, map[string]any{"profile": p})
+}
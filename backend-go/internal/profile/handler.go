@@ -1,21 +1,40 @@
 package profile
 
 import (
+  "context"
+  "errors"
   "net/http"
 
   "github.com/labstack/echo/v4"
   "github.com/jackc/pgx/v5"
   "github.com/jackc/pgx/v5/pgxpool"
 
+  "unichance-backend-go/internal/admissions"
+  "unichance-backend-go/internal/currency"
+  "unichance-backend-go/internal/i18n"
   "unichance-backend-go/internal/middleware"
+  "unichance-backend-go/internal/programs"
   "unichance-backend-go/internal/scoring"
 )
 
 type Handler struct {
   Repo Repo
+  Admissions admissions.Repo
+  Programs programs.Repo
+  Policy scoring.Policy // бос болса scoring.DefaultPolicy
+  FX *currency.Service // nil болса currency.Fallback
   DB *pgxpool.Pool
 }
 
+func (h Handler) policy() scoring.Policy {
+  if h.Policy.Name == "" { return scoring.DefaultPolicy() }
+  return h.Policy
+}
+
+func (h Handler) rates(ctx context.Context) scoring.Converter {
+  return h.FX.Current(ctx)
+}
+
 func (h Handler) GetMe(c echo.Context) error {
   u := c.Get("user").(middleware.CtxUser)
   p, err := h.Repo.GetMyProfile(c.Request().Context(), u.ID)
@@ -43,6 +62,8 @@ func (h Handler) UpsertMe(c echo.Context) error {
 
 type scoreReq struct {
   ProgramID string `json:"program_id"`
+  Mode string `json:"mode"` // "" | "rule" | "probability"
+  IncludeLiving bool `json:"include_living"`
 }
 
 func (h Handler) ScoreProgram(c echo.Context) error {
@@ -56,31 +77,18 @@ func (h Handler) ScoreProgram(c echo.Context) error {
   prof, err := h.Repo.GetMyProfile(c.Request().Context(), u.ID)
   if err != nil { return c.JSON(http.StatusBadRequest, map[string]string{"error":"profile not found"}) }
 
-  // requirements алу (егер requirements кестесі толса)
-  var r scoring.Requirements
-  err = h.DB.QueryRow(c.Request().Context(), `
-    SELECT min_gpa, min_ielts, min_toefl, min_sat
-    FROM requirements WHERE program_id=$1
-  `, req.ProgramID).Scan(&r.MinGPA, &r.MinIELTS, &r.MinTOEFL, &r.MinSAT)
-  // requirements жоқ болса — норма
-  if err != nil && err != pgx.ErrNoRows {
+  res, err := h.scoreOne(c.Request().Context(), prof, req.ProgramID, scoreOpts{
+    Probability: req.Mode == ModeProbability,
+    IncludeLiving: req.IncludeLiving,
+  })
+  if errors.Is(err, ErrProgramNotFound) { return c.JSON(http.StatusNotFound, map[string]string{"error": err.Error()}) }
+  if err != nil {
     return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
   }
 
-  res := scoring.Compute(scoring.Profile{
-    GPA: prof.GPA, GPAScale: prof.GPAScale,
-    IELTS: prof.IELTS, TOEFL: prof.TOEFL, SAT: prof.SAT,
-    BudgetYear: prof.BudgetYear,
-  }, r)
-
   // history-ге сақтау
-  _, _ = h.DB.Exec(c.Request().Context(), `
-    INSERT INTO scores(profile_id, program_id, score, reasons)
-    VALUES ($1,$2,$3,to_jsonb($4::text[]))
-  `, prof.ID, req.ProgramID, res.Score, res.Reasons)
+  h.saveScore(c.Request().Context(), prof.ID, res)
 
-  return c.JSON(http.StatusOK, map[string]any{
-    "score": res.Score,
-    "reasons": res.Reasons,
-  })
+  lang := i18n.Resolve(prof.Locale, c.Request().Header.Get("Accept-Language"))
+  return c.JSON(http.StatusOK, res.localize(lang))
 }
@@ -1,24 +1,62 @@
 package profile
 
+import "unichance-backend-go/internal/scoring"
+
 type Profile struct {
   ID string `json:"id"`
   UserID string `json:"user_id"`
 
   GPA *float64 `json:"gpa"`
   GPAScale *float64 `json:"gpa_scale"`
+  GradingSystem *string `json:"grading_system"` // grading.System коды (us_4, kz_5, de_5, ...)
 
   IELTS *float64 `json:"ielts"`
   TOEFL *int `json:"toefl"`
+  DET *int `json:"duolingo"` // Duolingo English Test, 10–160
+  PTE *int `json:"pte"` // PTE Academic, 10–90
+  Cambridge *int `json:"cambridge"` // Cambridge English Scale (C1/C2), 80–230
+
   SAT *int `json:"sat"`
+  ACT *int `json:"act"`
+  GRE *int `json:"gre"`
+  GMAT *int `json:"gmat"`
+  UNT *int `json:"unt"` // ЕНТ, 0–140
 
   BudgetYear *float64 `json:"budget_year"`
   BudgetCurrency *string `json:"budget_currency"`
 
   Awards *string `json:"awards"`
   AchievementsSummary *string `json:"achievements_summary"`
+
+  Locale *string `json:"locale"` // kk | ru | en; бос болса Accept-Language
 }
 
 type ScoreResult struct {
+  ProgramID string `json:"program_id"`
   Score int `json:"score"`
-  Reasons []string `json:"reasons"`
+  Reasons []scoring.Reason `json:"reasons"`
+  Recommendations []scoring.Recommendation `json:"recommendations"`
+
+  GPA4 *float64 `json:"gpa_4"`
+  GPAProgramScale *float64 `json:"gpa_program_scale"`
+
+  // тек "probability" режимінде
+  Probability *int `json:"probability,omitempty"`
+  Category scoring.Category `json:"category,omitempty"`
+  Model string `json:"model,omitempty"`
+
+  Policy string `json:"policy"`
+  PolicyVersion int `json:"policy_version"`
+
+  Affordability *scoring.Affordability `json:"affordability,omitempty"`
+}
+
+func (p Profile) scoringProfile() scoring.Profile {
+  return scoring.Profile{
+    GPA: p.GPA, GPAScale: p.GPAScale, GradingSystem: strOrEmpty(p.GradingSystem),
+    IELTS: p.IELTS, TOEFL: p.TOEFL, DET: p.DET, PTE: p.PTE, Cambridge: p.Cambridge,
+    SAT: p.SAT, ACT: p.ACT, GRE: p.GRE, GMAT: p.GMAT, UNT: p.UNT,
+    BudgetYear: p.BudgetYear, BudgetCurrency: strOrEmpty(p.BudgetCurrency),
+    Awards: p.Awards, AchievementsSummary: p.AchievementsSummary,
+  }
 }
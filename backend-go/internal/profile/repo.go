@@ -2,44 +2,75 @@ package profile
 
 import (
   "context"
+  "errors"
+  "fmt"
+
   "github.com/jackc/pgx/v5/pgxpool"
+
+  "unichance-backend-go/internal/currency"
+  "unichance-backend-go/internal/grading"
+  "unichance-backend-go/internal/i18n"
 )
 
 type Repo struct { DB *pgxpool.Pool }
 
+// profileCols — RETURNING/SELECT бағандары, scanProfile ретімен
+const profileCols = `id, user_id, gpa, gpa_scale, grading_system,
+  ielts, toefl, duolingo, pte, cambridge,
+  sat, act, gre, gmat, unt,
+  budget_year, budget_currency, awards, achievements_summary, locale`
+
 func (r Repo) UpsertMyProfile(ctx context.Context, userID string, p Profile) (Profile, error) {
+  if err := validateGPA(p); err != nil { return Profile{}, err }
+  if err := validateTests(p); err != nil { return Profile{}, err }
+  if l := strOrEmpty(p.Locale); l != "" && !i18n.Supported(l) {
+    return Profile{}, errors.New("unsupported locale: " + l)
+  }
+  if err := r.validateCurrency(ctx, &p); err != nil { return Profile{}, err }
+
   // 1 user = 1 profile (MVP)
   q := `
-  INSERT INTO profiles(user_id,gpa,gpa_scale,ielts,toefl,sat,budget_year,budget_currency,awards,achievements_summary)
-  VALUES ($1,$2,$3,$4,$5,$6,$7,NULLIF($8,'')::tuition_currency,$9,$10)
+  INSERT INTO profiles(
+    user_id,gpa,gpa_scale,grading_system,
+    ielts,toefl,duolingo,pte,cambridge,
+    sat,act,gre,gmat,unt,
+    budget_year,budget_currency,awards,achievements_summary,locale
+  )
+  VALUES ($1,$2,$3,NULLIF($4,''),$5,$6,$7,$8,$9,$10,$11,$12,$13,$14,$15,NULLIF($16,''),$17,$18,NULLIF($19,''))
   ON CONFLICT (user_id) DO UPDATE SET
     gpa=EXCLUDED.gpa,
     gpa_scale=EXCLUDED.gpa_scale,
+    grading_system=EXCLUDED.grading_system,
     ielts=EXCLUDED.ielts,
     toefl=EXCLUDED.toefl,
+    duolingo=EXCLUDED.duolingo,
+    pte=EXCLUDED.pte,
+    cambridge=EXCLUDED.cambridge,
     sat=EXCLUDED.sat,
+    act=EXCLUDED.act,
+    gre=EXCLUDED.gre,
+    gmat=EXCLUDED.gmat,
+    unt=EXCLUDED.unt,
     budget_year=EXCLUDED.budget_year,
     budget_currency=EXCLUDED.budget_currency,
     awards=EXCLUDED.awards,
     achievements_summary=EXCLUDED.achievements_summary,
+    locale=EXCLUDED.locale,
     updated_at=now()
-  RETURNING id, user_id, gpa, gpa_scale, ielts, toefl, sat, budget_year, budget_currency::text, awards, achievements_summary
-  `
-  // NOTE: profiles.user_id unique керек. Қазір миграцияда жоқ. Төменде қосамыз.
+  RETURNING ` + profileCols
   return r.scanProfile(ctx, q,
     userID,
-    p.GPA, p.GPAScale, p.IELTS, p.TOEFL, p.SAT,
+    p.GPA, p.GPAScale, strOrEmpty(p.GradingSystem),
+    p.IELTS, p.TOEFL, p.DET, p.PTE, p.Cambridge,
+    p.SAT, p.ACT, p.GRE, p.GMAT, p.UNT,
     p.BudgetYear, strOrEmpty(p.BudgetCurrency),
     p.Awards, p.AchievementsSummary,
+    strOrEmpty(p.Locale),
   )
 }
 
 func (r Repo) GetMyProfile(ctx context.Context, userID string) (Profile, error) {
-  q := `
-  SELECT id, user_id, gpa, gpa_scale, ielts, toefl, sat, budget_year, budget_currency::text, awards, achievements_summary
-  FROM profiles
-  WHERE user_id=$1
-  `
+  q := `SELECT ` + profileCols + ` FROM profiles WHERE user_id=$1`
   return r.scanProfile(ctx, q, userID)
 }
 
@@ -48,15 +79,68 @@ func (r Repo) scanProfile(ctx context.Context, q string, args ...any) (Profile,
   var cur *string
   err := r.DB.QueryRow(ctx, q, args...).Scan(
     &p.ID, &p.UserID,
-    &p.GPA, &p.GPAScale,
-    &p.IELTS, &p.TOEFL, &p.SAT,
+    &p.GPA, &p.GPAScale, &p.GradingSystem,
+    &p.IELTS, &p.TOEFL, &p.DET, &p.PTE, &p.Cambridge,
+    &p.SAT, &p.ACT, &p.GRE, &p.GMAT, &p.UNT,
     &p.BudgetYear, &cur,
     &p.Awards, &p.AchievementsSummary,
+    &p.Locale,
   )
   if cur != nil { p.BudgetCurrency = cur }
   return p, err
 }
 
+func validateGPA(p Profile) error {
+  code := strOrEmpty(p.GradingSystem)
+  if code == "" { return nil }
+  sys, ok := grading.Lookup(code)
+  if !ok { return errors.New("unknown grading_system: " + code) }
+  if p.GPA != nil && !sys.Valid(*p.GPA) {
+    return fmt.Errorf("gpa must be between %g and %g for %s", sys.Min, sys.Max, code)
+  }
+  return nil
+}
+
+// validateCurrency normalizes budget_currency and checks it against currencies (ISO 4217).
+func (r Repo) validateCurrency(ctx context.Context, p *Profile) error {
+  code := currency.Normalize(strOrEmpty(p.BudgetCurrency))
+  if code == "" { return nil }
+  ok, err := currency.Repo{DB: r.DB}.Known(ctx, code)
+  if err != nil { return err }
+  if !ok { return errors.New("unknown budget_currency: " + code) }
+  p.BudgetCurrency = &code
+  return nil
+}
+
+// testRanges — әр тесттің ресми балл диапазоны
+var testRanges = []struct {
+  name string
+  get func(Profile) *int
+  min, max int
+}{
+  {"toefl", func(p Profile) *int { return p.TOEFL }, 0, 120},
+  {"duolingo", func(p Profile) *int { return p.DET }, 10, 160},
+  {"pte", func(p Profile) *int { return p.PTE }, 10, 90},
+  {"cambridge", func(p Profile) *int { return p.Cambridge }, 80, 230},
+  {"sat", func(p Profile) *int { return p.SAT }, 400, 1600},
+  {"act", func(p Profile) *int { return p.ACT }, 1, 36},
+  {"gre", func(p Profile) *int { return p.GRE }, 260, 340},
+  {"gmat", func(p Profile) *int { return p.GMAT }, 200, 800},
+  {"unt", func(p Profile) *int { return p.UNT }, 0, 140},
+}
+
+func validateTests(p Profile) error {
+  if p.IELTS != nil && (*p.IELTS < 0 || *p.IELTS > 9) {
+    return errors.New("ielts must be between 0 and 9")
+  }
+  for _, t := range testRanges {
+    if v := t.get(p); v != nil && (*v < t.min || *v > t.max) {
+      return fmt.Errorf("%s must be between %d and %d", t.name, t.min, t.max)
+    }
+  }
+  return nil
+}
+
 func strOrEmpty(s *string) string {
   if s == nil { return "" }
   return *s
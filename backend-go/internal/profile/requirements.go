@@ -0,0 +1,85 @@
+package profile
+
+import (
+  "context"
+
+  "github.com/jackc/pgx/v5/pgxpool"
+
+  "unichance-backend-go/internal/scoring"
+)
+
+// loadRequirementsMany reads requirements for many programs in one query.
+// Every existing program gets a key (zero value without a requirements row),
+// so a missing key means the program does not exist.
+func loadRequirementsMany(ctx context.Context, db *pgxpool.Pool, programIDs []string) (map[string]scoring.Requirements, error) {
+  rows, err := db.Query(ctx, `
+    SELECT
+      p.id::text,
+      r.min_gpa, COALESCE(r.gpa_system,''),
+      r.min_ielts, r.min_toefl, r.min_duolingo, r.min_pte, r.min_cambridge,
+      r.min_sat, r.min_act, r.min_gre, r.min_gmat, r.min_unt,
+      p.degree_level::text
+    FROM programs p
+    LEFT JOIN requirements r ON r.program_id = p.id
+    WHERE p.id = ANY($1::uuid[])
+  `, programIDs)
+  if err != nil { return nil, err }
+  defer rows.Close()
+
+  out := map[string]scoring.Requirements{}
+  for rows.Next() {
+    var id string
+    var r scoring.Requirements
+    if err := rows.Scan(
+      &id,
+      &r.MinGPA, &r.GPASystem,
+      &r.MinIELTS, &r.MinTOEFL, &r.MinDET, &r.MinPTE, &r.MinCambridge,
+      &r.MinSAT, &r.MinACT, &r.MinGRE, &r.MinGMAT, &r.MinUNT,
+      &r.DegreeLevel,
+    ); err != nil {
+      return nil, err
+    }
+    out[id] = r
+  }
+  return out, rows.Err()
+}
+
+// loadCostsMany reads tuition/scholarship data and, when includeLiving is set,
+// the city living cost (falling back to the country-wide row).
+func loadCostsMany(ctx context.Context, db *pgxpool.Pool, programIDs []string, includeLiving bool) (map[string]scoring.Cost, error) {
+  rows, err := db.Query(ctx, `
+    SELECT
+      p.id::text,
+      p.tuition_amount::float8, COALESCE(p.tuition_currency::text,''),
+      p.has_scholarship, p.scholarship_percent_min, p.scholarship_percent_max,
+      lc.amount_year::float8, COALESCE(lc.currency::text,'')
+    FROM programs p
+    JOIN universities u ON u.id = p.university_id
+    LEFT JOIN LATERAL (
+      SELECT c.amount_year, c.currency
+      FROM city_living_costs c
+      WHERE $2 AND c.country_code = u.country_code AND (c.city IS NULL OR lower(c.city) = lower(u.city))
+      ORDER BY c.city NULLS LAST
+      LIMIT 1
+    ) lc ON true
+    WHERE p.id = ANY($1::uuid[])
+  `, programIDs, includeLiving)
+  if err != nil { return nil, err }
+  defer rows.Close()
+
+  out := map[string]scoring.Cost{}
+  for rows.Next() {
+    var id string
+    var c scoring.Cost
+    if err := rows.Scan(
+      &id,
+      &c.TuitionAmount, &c.TuitionCurrency,
+      &c.HasScholarship, &c.ScholarshipPercentMin, &c.ScholarshipPercentMax,
+      &c.LivingAmount, &c.LivingCurrency,
+    ); err != nil {
+      return nil, err
+    }
+    out[id] = c
+  }
+  return out, rows.Err()
+}
@@ -0,0 +1,155 @@
+package profile
+
+import (
+  "context"
+  "encoding/json"
+  "errors"
+
+  "unichance-backend-go/internal/admissions"
+  "unichance-backend-go/internal/i18n"
+  "unichance-backend-go/internal/scoring"
+)
+
+const (
+  ModeRule = "rule"
+  ModeProbability = "probability"
+)
+
+var ErrProgramNotFound = errors.New("program not found")
+
+// scoreOpts — бір сұраудағы барлық бағдарламаларға ортақ параметрлер.
+type scoreOpts struct {
+  Probability bool // "probability" режимі
+  IncludeLiving bool // affordability-ге қала тұру шығынын қосу
+  SkipRecommend bool // карточкаларға тек ұпай керек (GET /programs, compare)
+}
+
+// scoreOne loads the program's requirements (and stats in probability mode)
+// and evaluates prof against them. Every score endpoint goes through
+// scoreLoaded, so /score and the what-if simulator return identical numbers.
+func (h Handler) scoreOne(ctx context.Context, prof Profile, programID string, opts scoreOpts) (ScoreResult, error) {
+  res, err := h.scoreMany(ctx, prof, []string{programID}, opts)
+  if err != nil { return ScoreResult{}, err }
+  if len(res) == 0 { return ScoreResult{}, ErrProgramNotFound }
+  return res[0], nil
+}
+
+// scoreMany evaluates many programs; requirements, costs and stats are
+// loaded with one query each. IDs not in programs are dropped (see missingIDs).
+func (h Handler) scoreMany(ctx context.Context, prof Profile, programIDs []string, opts scoreOpts) ([]ScoreResult, error) {
+  d, err := h.loadPrograms(ctx, programIDs, opts)
+  if err != nil { return nil, err }
+  return h.scoreLoaded(prof, d, opts), nil
+}
+
+// programData — scoreMany жүктейтін деректер. Бір жүктемені бірнеше профильге
+// қолдануға болады (simulate: before/after).
+type programData struct {
+  ids []string
+  reqs map[string]scoring.Requirements
+  costs map[string]scoring.Cost
+  summaries map[string]*admissions.Summary // тек probability режимінде
+  rates scoring.Converter
+}
+
+func (h Handler) loadPrograms(ctx context.Context, programIDs []string, opts scoreOpts) (programData, error) {
+  d := programData{ids: programIDs, rates: h.rates(ctx)}
+  var err error
+  if d.reqs, err = loadRequirementsMany(ctx, h.DB, programIDs); err != nil { return programData{}, err }
+  if d.costs, err = loadCostsMany(ctx, h.DB, programIDs, opts.IncludeLiving); err != nil { return programData{}, err }
+  if opts.Probability {
+    if d.summaries, err = h.Admissions.SummaryMany(ctx, programIDs); err != nil { return programData{}, err }
+  }
+  return d, nil
+}
+
+// scoreLoaded evaluates prof against already loaded programs, in d.ids order.
+func (h Handler) scoreLoaded(prof Profile, d programData, opts scoreOpts) []ScoreResult {
+  sp := prof.scoringProfile()
+  out := make([]ScoreResult, 0, len(d.ids))
+  for _, id := range d.ids {
+    r, ok := d.reqs[id]
+    if !ok { continue }
+    res := h.evaluate(sp, id, r, d.summaries[id].ScoringStats(), opts)
+    if c, ok := d.costs[id]; ok {
+      a := scoring.Afford(sp, c, d.rates)
+      res.Affordability = &a
+    }
+    out = append(out, res)
+  }
+  return out
+}
+
+// missingIDs returns the requested IDs that got no result.
+func missingIDs(programIDs []string, results []ScoreResult) []string {
+  seen := make(map[string]bool, len(results))
+  for _, r := range results { seen[r.ProgramID] = true }
+  out := []string{}
+  for _, id := range programIDs {
+    if !seen[id] { out = append(out, id) }
+  }
+  return out
+}
+
+func (h Handler) evaluate(sp scoring.Profile, programID string, r scoring.Requirements, stats *scoring.Stats, opts scoreOpts) ScoreResult {
+  pol := h.policy()
+
+  var res ScoreResult
+  var base scoring.Result
+  if opts.Probability {
+    // статистика жоқ болса Predict rule-based score-ға түседі
+    pred := pol.Predict(sp, r, stats)
+    base = pred.Result
+    prob := pred.Probability
+    res.Probability, res.Category, res.Model = &prob, pred.Category, pred.Model
+  } else {
+    base = pol.Compute(sp, r)
+  }
+
+  res.ProgramID = programID
+  res.Score = base.Score
+  res.Reasons = base.Reasons
+  if !opts.SkipRecommend { res.Recommendations = pol.Recommend(sp, r, stats) }
+  res.GPA4, res.GPAProgramScale = base.GPA4, base.GPAProgramScale
+  res.Policy, res.PolicyVersion = base.PolicyName, base.PolicyVersion
+  return res
+}
+
+// saveScore writes the score history row (reason codes only, no text).
+func (h Handler) saveScore(ctx context.Context, profileID string, res ScoreResult) {
+  reasonsJSON, _ := json.Marshal(res.Reasons)
+  var category *string
+  if res.Category != "" { c := string(res.Category); category = &c }
+  var model *string
+  if res.Model != "" { model = &res.Model }
+
+  _, _ = h.DB.Exec(ctx, `
+    INSERT INTO scores(profile_id, program_id, score, reasons, probability, category, model, policy_name, policy_version)
+    VALUES ($1,$2,$3,$4::jsonb,$5,$6,$7,$8,$9)
+  `, profileID, res.ProgramID, res.Score, reasonsJSON, res.Probability, category, model, res.Policy, res.PolicyVersion)
+}
+
+func (res ScoreResult) localize(lang i18n.Lang) ScoreResult {
+  res.Reasons = localizeReasons(lang, res.Reasons)
+  res.Recommendations = localizeRecommendations(lang, res.Recommendations)
+  return res
+}
+
+// localizeReasons fills Message from the i18n catalog.
+func localizeReasons(lang i18n.Lang, reasons []scoring.Reason) []scoring.Reason {
+  out := make([]scoring.Reason, len(reasons))
+  for i, r := range reasons {
+    r.Message = i18n.T(lang, "reason."+r.Code, r.Args())
+    out[i] = r
+  }
+  return out
+}
+
+func localizeRecommendations(lang i18n.Lang, recs []scoring.Recommendation) []scoring.Recommendation {
+  out := make([]scoring.Recommendation, len(recs))
+  for i, rec := range recs {
+    rec.Message = i18n.T(lang, "recommendation."+rec.Code, rec.Args())
+    out[i] = rec
+  }
+  return out
+}
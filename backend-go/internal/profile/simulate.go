@@ -0,0 +1,147 @@
+package profile
+
+import (
+  "net/http"
+
+  "github.com/labstack/echo/v4"
+
+  "unichance-backend-go/internal/i18n"
+  "unichance-backend-go/internal/middleware"
+)
+
+// MaxSimulatePrograms — бір what-if сұрауындағы бағдарламалар шегі.
+const MaxSimulatePrograms = 20
+
+// Overrides — сақталған профильдің үстіне қойылатын гипотетикалық мәндер.
+// nil өріс = өзгеріссіз.
+type Overrides struct {
+  GPA *float64 `json:"gpa"`
+  GPAScale *float64 `json:"gpa_scale"`
+  GradingSystem *string `json:"grading_system"`
+
+  IELTS *float64 `json:"ielts"`
+  TOEFL *int `json:"toefl"`
+  DET *int `json:"duolingo"`
+  PTE *int `json:"pte"`
+  Cambridge *int `json:"cambridge"`
+
+  SAT *int `json:"sat"`
+  ACT *int `json:"act"`
+  GRE *int `json:"gre"`
+  GMAT *int `json:"gmat"`
+  UNT *int `json:"unt"`
+
+  BudgetYear *float64 `json:"budget_year"`
+  BudgetCurrency *string `json:"budget_currency"`
+
+  Awards *string `json:"awards"`
+  AchievementsSummary *string `json:"achievements_summary"`
+}
+
+func (o Overrides) apply(p Profile) Profile {
+  set := func(dst **float64, v *float64) { if v != nil { *dst = v } }
+  seti := func(dst **int, v *int) { if v != nil { *dst = v } }
+  sets := func(dst **string, v *string) { if v != nil { *dst = v } }
+
+  set(&p.GPA, o.GPA)
+  set(&p.GPAScale, o.GPAScale)
+  sets(&p.GradingSystem, o.GradingSystem)
+  set(&p.IELTS, o.IELTS)
+  seti(&p.TOEFL, o.TOEFL)
+  seti(&p.DET, o.DET)
+  seti(&p.PTE, o.PTE)
+  seti(&p.Cambridge, o.Cambridge)
+  seti(&p.SAT, o.SAT)
+  seti(&p.ACT, o.ACT)
+  seti(&p.GRE, o.GRE)
+  seti(&p.GMAT, o.GMAT)
+  seti(&p.UNT, o.UNT)
+  set(&p.BudgetYear, o.BudgetYear)
+  sets(&p.BudgetCurrency, o.BudgetCurrency)
+  sets(&p.Awards, o.Awards)
+  sets(&p.AchievementsSummary, o.AchievementsSummary)
+  return p
+}
+
+type simulateReq struct {
+  ProgramID string `json:"program_id"`
+  ProgramIDs []string `json:"program_ids"`
+  Mode string `json:"mode"`
+  IncludeLiving bool `json:"include_living"`
+  Overrides Overrides `json:"overrides"`
+}
+
+type simulateItem struct {
+  ProgramID string `json:"program_id"`
+  Before ScoreResult `json:"before"`
+  After ScoreResult `json:"after"`
+  ScoreDelta int `json:"score_delta"`
+  ProbabilityDelta *int `json:"probability_delta,omitempty"`
+}
+
+// Simulate — "IELTS 7.5 алсам ше?" сұрағы. Сақталған профиль + overrides
+// бойынша бұрынғы/кейінгі ұпайларды қайтарады; scores тарихына жазбайды.
+func (h Handler) Simulate(c echo.Context) error {
+  u := c.Get("user").(middleware.CtxUser)
+  ctx := c.Request().Context()
+
+  var req simulateReq
+  if err := c.Bind(&req); err != nil {
+    return c.JSON(http.StatusBadRequest, map[string]string{"error":"bad body"})
+  }
+  ids := req.ProgramIDs
+  if req.ProgramID != "" { ids = append([]string{req.ProgramID}, ids...) }
+  if len(ids) == 0 {
+    return c.JSON(http.StatusBadRequest, map[string]string{"error":"program_id or program_ids required"})
+  }
+  if len(ids) > MaxSimulatePrograms {
+    return c.JSON(http.StatusBadRequest, map[string]string{"error":"too many program_ids"})
+  }
+
+  prof, err := h.Repo.GetMyProfile(ctx, u.ID)
+  if err != nil { return c.JSON(http.StatusBadRequest, map[string]string{"error":"profile not found"}) }
+
+  hypo := req.Overrides.apply(prof)
+  if err := validateGPA(hypo); err != nil {
+    return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+  }
+  if err := validateTests(hypo); err != nil {
+    return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+  }
+  if err := h.Repo.validateCurrency(ctx, &hypo); err != nil {
+    return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+  }
+
+  lang := i18n.Resolve(prof.Locale, c.Request().Header.Get("Accept-Language"))
+  opts := scoreOpts{Probability: req.Mode == ModeProbability, IncludeLiving: req.IncludeLiving}
+
+  // бағдарламалар бір рет жүктеледі, prof пен hypo сол деректермен бағаланады
+  data, err := h.loadPrograms(ctx, ids, opts)
+  if err != nil { return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()}) }
+  before := h.scoreLoaded(prof, data, opts)
+  if missing := missingIDs(ids, before); len(missing) > 0 {
+    return c.JSON(http.StatusNotFound, map[string]string{"error": ErrProgramNotFound.Error(), "program_id": missing[0]})
+  }
+  after := h.scoreLoaded(hypo, data, opts)
+
+  items := make([]simulateItem, 0, len(ids))
+  for i, b := range before {
+    a := after[i]
+    it := simulateItem{
+      ProgramID: b.ProgramID,
+      Before: b.localize(lang),
+      After: a.localize(lang),
+      ScoreDelta: a.Score - b.Score,
+    }
+    if b.Probability != nil && a.Probability != nil {
+      d := *a.Probability - *b.Probability
+      it.ProbabilityDelta = &d
+    }
+    items = append(items, it)
+  }
+
+  return c.JSON(http.StatusOK, map[string]any{
+    "overrides": req.Overrides,
+    "items": items,
+  })
+}
@@ -0,0 +1,264 @@
+package programs
+
+import (
+  "context"
+  "fmt"
+  "math"
+  "net/http"
+  "sort"
+
+  "github.com/labstack/echo/v4"
+
+  "unichance-backend-go/internal/middleware"
+  "unichance-backend-go/internal/scoring"
+)
+
+// Compare шектері (shortlist 3–5 бағдарлама).
+const (
+  MinCompare = 2
+  MaxCompare = 5
+)
+
+// ScoreDetail — салыстыру бағанындағы пайдаланушы ұпайы: CardScore + орындалмаған талаптар.
+type ScoreDetail struct {
+  CardScore
+  Gaps []scoring.Reason `json:"gaps"` // warning/blocker себептері, аударылған
+}
+
+// CompareRow — матрицаның бір жолы; Values Programs ретімен (мән жоқ болса null).
+type CompareRow struct {
+  Key string `json:"key"`
+  Group string `json:"group"` // cost | program | ranking | requirements | deadlines | you
+  Values []any `json:"values"`
+  Best []int `json:"best,omitempty"` // ең жақсы мәні бар бағандар
+}
+
+type Comparison struct {
+  Currency string `json:"currency"`
+  Programs []ProgramCard `json:"programs"`
+  Rows []CompareRow `json:"rows"`
+}
+
+// Compare — GET /programs/compare?ids=a,b,c&display_currency=EUR
+func (h Handler) Compare(c echo.Context) error {
+  ctx := c.Request().Context()
+  ids := dedupe(splitCSV(c.QueryParam("ids")))
+  if len(ids) < MinCompare || len(ids) > MaxCompare {
+    return c.JSON(http.StatusBadRequest, map[string]string{"error": fmt.Sprintf("ids must contain %d to %d programs", MinCompare, MaxCompare)})
+  }
+
+  cards, err := h.Repo.Cards(ctx, ids, c.QueryParam("display_currency"))
+  if err != nil { return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()}) }
+  if len(cards) != len(ids) {
+    return c.JSON(http.StatusNotFound, map[string]string{"error": "program not found"})
+  }
+
+  reqs := make([]*Requirements, len(cards))
+  deadlines := make([][]Deadline, len(cards))
+  for i, p := range cards {
+    if reqs[i], err = h.Repo.requirements(ctx, p.ID); err != nil {
+      return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+    }
+    if deadlines[i], err = h.Repo.deadlines(ctx, p.ID); err != nil {
+      return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+    }
+  }
+
+  var scores map[string]ScoreDetail
+  if u, ok := middleware.UserFrom(c); ok && h.Scorer != nil {
+    scores, err = h.Scorer.ScoreDetails(ctx, u.ID, c.Request().Header.Get("Accept-Language"), ids)
+    if err != nil { return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()}) }
+  }
+
+  return c.JSON(http.StatusOK, compareMatrix(cards, reqs, deadlines, scores))
+}
+
+// Cards returns program cards in the order of ids (unknown ids are skipped).
+func (r Repo) Cards(ctx context.Context, ids []string, displayCurrency string) ([]ProgramCard, error) {
+  f, err := r.buildFilter(ctx, ListParams{DisplayCurrency: displayCurrency})
+  if err != nil { return nil, err }
+  _, args := f.where("")
+  args = append(args, ids)
+
+  rows, err := r.DB.Query(ctx, `
+    SELECT `+f.cardCols()+f.from()+`
+    WHERE programs.id = ANY($`+fmt.Sprint(len(args))+`::uuid[])`, args...)
+  if err != nil { return nil, err }
+  defer rows.Close()
+
+  byID := map[string]ProgramCard{}
+  for rows.Next() {
+    var it ProgramCard
+    if err := rows.Scan(it.dest()...); err != nil { return nil, err }
+    it.DisplayCurrency = f.display
+    byID[it.ID] = it
+  }
+  if err := rows.Err(); err != nil { return nil, err }
+
+  out := make([]ProgramCard, 0, len(ids))
+  for _, id := range ids {
+    if it, ok := byID[id]; ok { out = append(out, it) }
+  }
+  return out, nil
+}
+
+func compareMatrix(cards []ProgramCard, reqs []*Requirements, deadlines [][]Deadline, scores map[string]ScoreDetail) Comparison {
+  n := len(cards)
+  cmp := Comparison{Programs: cards}
+  if n > 0 { cmp.Currency = cards[0].DisplayCurrency }
+
+  row := func(key, group string, get func(i int) any) CompareRow {
+    r := CompareRow{Key: key, Group: group, Values: make([]any, n)}
+    for i := range cards { r.Values[i] = get(i) }
+    return r
+  }
+  // numRow — сандық жол; lower=true болса ең кіші мән ең жақсы
+  numRow := func(key, group string, lower bool, get func(i int) *float64) CompareRow {
+    r := CompareRow{Key: key, Group: group, Values: make([]any, n)}
+    vals := make([]*float64, n)
+    for i := range cards {
+      vals[i] = get(i)
+      if vals[i] != nil { r.Values[i] = *vals[i] }
+    }
+    r.Best = best(vals, lower)
+    return r
+  }
+  req := func(i int) Requirements {
+    if reqs[i] == nil { return Requirements{} }
+    return *reqs[i]
+  }
+
+  cmp.Rows = append(cmp.Rows,
+    // cost
+    numRow("tuition", "cost", true, func(i int) *float64 { return cards[i].DisplayAmount }),
+    row("tuition_original", "cost", func(i int) any {
+      if cards[i].TuitionAmount == nil { return nil }
+      return map[string]any{"amount": *cards[i].TuitionAmount, "currency": cards[i].TuitionCurrency}
+    }),
+    row("has_scholarship", "cost", func(i int) any { return cards[i].HasScholarship }),
+    row("scholarship_type", "cost", func(i int) any { return cards[i].ScholarshipType }),
+    row("scholarship_percent", "cost", func(i int) any {
+      if !cards[i].HasScholarship { return nil }
+      return map[string]any{"min": cards[i].ScholarshipPercentMin, "max": cards[i].ScholarshipPercentMax}
+    }),
+    numRow("tuition_best_case", "cost", true, func(i int) *float64 { return bestCase(cards[i]) }),
+
+    // program
+    row("degree_level", "program", func(i int) any { return cards[i].DegreeLevel }),
+    row("field", "program", func(i int) any { return cards[i].Field }),
+    row("language", "program", func(i int) any { return cards[i].Language }),
+    row("university", "program", func(i int) any { return cards[i].UniversityName }),
+    row("country", "program", func(i int) any { return cards[i].CountryCode }),
+    row("city", "program", func(i int) any { return cards[i].City }),
+
+    // ranking
+    numRow("qs_rank", "ranking", true, func(i int) *float64 { return intF(cards[i].QSRank) }),
+    numRow("the_rank", "ranking", true, func(i int) *float64 { return intF(cards[i].THERank) }),
+
+    // requirements (мәндер бағдарламаның өз шкаласында)
+    row("min_gpa", "requirements", func(i int) any {
+      r := req(i)
+      if r.MinGPA == nil { return nil }
+      return map[string]any{"value": *r.MinGPA, "system": r.GPASystem}
+    }),
+    numRow("min_ielts", "requirements", true, func(i int) *float64 { return req(i).MinIELTS }),
+    numRow("min_toefl", "requirements", true, func(i int) *float64 { return intF(req(i).MinTOEFL) }),
+    numRow("min_duolingo", "requirements", true, func(i int) *float64 { return intF(req(i).MinDET) }),
+    numRow("min_pte", "requirements", true, func(i int) *float64 { return intF(req(i).MinPTE) }),
+    numRow("min_cambridge", "requirements", true, func(i int) *float64 { return intF(req(i).MinCambridge) }),
+    numRow("min_sat", "requirements", true, func(i int) *float64 { return intF(req(i).MinSAT) }),
+    numRow("min_act", "requirements", true, func(i int) *float64 { return intF(req(i).MinACT) }),
+    numRow("min_gre", "requirements", true, func(i int) *float64 { return intF(req(i).MinGRE) }),
+    numRow("min_gmat", "requirements", true, func(i int) *float64 { return intF(req(i).MinGMAT) }),
+    numRow("min_unt", "requirements", true, func(i int) *float64 { return intF(req(i).MinUNT) }),
+
+    // deadlines
+    row("next_deadline", "deadlines", func(i int) any {
+      for _, d := range deadlines[i] {
+        if !d.Passed { return d }
+      }
+      return nil
+    }),
+    row("deadlines", "deadlines", func(i int) any { return deadlines[i] }),
+  )
+
+  if scores != nil {
+    score := func(i int) *ScoreDetail {
+      if s, ok := scores[cards[i].ID]; ok { return &s }
+      return nil
+    }
+    cmp.Rows = append(cmp.Rows,
+      numRow("score", "you", false, func(i int) *float64 {
+        if s := score(i); s != nil { return intF(&s.Score) }
+        return nil
+      }),
+      numRow("probability", "you", false, func(i int) *float64 {
+        if s := score(i); s != nil { return intF(s.Probability) }
+        return nil
+      }),
+      row("category", "you", func(i int) any {
+        if s := score(i); s != nil && s.Category != "" { return s.Category }
+        return nil
+      }),
+      row("affordability", "you", func(i int) any {
+        if s := score(i); s != nil && s.Affordability != "" { return s.Affordability }
+        return nil
+      }),
+      row("gaps", "you", func(i int) any {
+        if s := score(i); s != nil { return s.Gaps }
+        return nil
+      }),
+    )
+  }
+  return cmp
+}
+
+// bestCase — ең жоғары грантпен tuition (display currency-де).
+func bestCase(c ProgramCard) *float64 {
+  if c.DisplayAmount == nil { return nil }
+  v := *c.DisplayAmount
+  if c.HasScholarship && c.ScholarshipPercentMax != nil {
+    pct := math.Max(0, math.Min(100, float64(*c.ScholarshipPercentMax)))
+    v = math.Round(v*(100-pct)) / 100
+  }
+  return &v
+}
+
+// best returns the indexes holding the lowest (or highest) value; nil when
+// fewer than two columns have a value or all values are equal.
+func best(vals []*float64, lower bool) []int {
+  var out []int
+  var top float64
+  set := 0
+  for i, v := range vals {
+    if v == nil { continue }
+    set++
+    better := len(out) == 0 || (lower && *v < top) || (!lower && *v > top)
+    switch {
+    case better:
+      top, out = *v, []int{i}
+    case *v == top:
+      out = append(out, i)
+    }
+  }
+  if set < 2 || len(out) == set { return nil }
+  sort.Ints(out)
+  return out
+}
+
+func intF(v *int) *float64 {
+  if v == nil { return nil }
+  f := float64(*v)
+  return &f
+}
+
+func dedupe(ids []string) []string {
+  seen := map[string]bool{}
+  out := make([]string, 0, len(ids))
+  for _, id := range ids {
+    if seen[id] { continue }
+    seen[id] = true
+    out = append(out, id)
+  }
+  return out
+}
@@ -0,0 +1,40 @@
+package programs
+
+import (
+  "unichance-backend-go/internal/keyset"
+)
+
+var ErrBadCursor = keyset.ErrBadCursor
+
+var (
+  keyQS = keyset.Key{Expr: "COALESCE(universities.qs_rank, " + keyset.NoRank + ")", Kind: "int"}
+  keyTHE = keyset.Key{Expr: "COALESCE(universities.the_rank, " + keyset.NoRank + ")", Kind: "int"}
+  keyTitle = keyset.Key{Expr: "programs.title", Kind: "text"}
+  keyID = keyset.Key{Expr: "programs.id", Kind: "uuid"} // тай-брейкер, әр сұрыптың соңында
+)
+
+// sortKeys returns the canonical sort name and its keyset keys.
+func (f filter) sortKeys(sort string) (string, []keyset.Key) {
+  if f.useFTS && (sort == "" || sort == "relevance") {
+    rank := keyset.Key{Expr: "(-" + searchRank + ")::float8", Kind: "float"}
+    return "relevance", []keyset.Key{rank, keyQS, keyTHE, keyTitle, keyID}
+  }
+  switch sort {
+  case "tuition_asc":
+    return sort, []keyset.Key{{Expr: "COALESCE(" + f.amountSQL + ", 1e300)", Kind: "float"}, keyID}
+  case "tuition_desc":
+    return sort, []keyset.Key{{Expr: "COALESCE(-" + f.amountSQL + ", 1e300)", Kind: "float"}, keyID}
+  case "qs":
+    return sort, []keyset.Key{keyQS, keyID}
+  case "the":
+    return sort, []keyset.Key{keyTHE, keyID}
+  }
+  return "default", []keyset.Key{keyQS, keyTHE, keyTitle, keyID}
+}
+
+// filterHash fingerprints everything except paging, so a cursor cannot be
+// replayed against a different search.
+func filterHash(p ListParams) string {
+  p.Cursor, p.Page, p.Limit, p.SkipTotal = "", 0, 0, false
+  return keyset.Hash(p)
+}
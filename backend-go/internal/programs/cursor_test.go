@@ -0,0 +1,49 @@
+package programs
+
+import "testing"
+
+func TestFilterHashIgnoresPaging(t *testing.T) {
+  base := ListParams{Q: "data science", Countries: []string{"DE"}, Sort: "qs"}
+  paged := base
+  paged.Page, paged.Limit, paged.Cursor, paged.SkipTotal = 3, 50, "token", true
+  if filterHash(base) != filterHash(paged) { t.Error("paging fields change the filter hash") }
+
+  tests := []func(*ListParams){
+    func(p *ListParams) { p.Q = "data" },
+    func(p *ListParams) { p.Countries = []string{"NL"} },
+    func(p *ListParams) { p.Sort = "the" },
+    func(p *ListParams) { p.DisplayCurrency = "EUR" },
+  }
+  for i, edit := range tests {
+    p := base
+    edit(&p)
+    if filterHash(p) == filterHash(base) { t.Errorf("case %d: filter change keeps the hash", i) }
+  }
+}
+
+func TestSortKeys(t *testing.T) {
+  tests := []struct {
+    fts bool
+    sort string
+    want string
+    keys int
+  }{
+    {true, "", "relevance", 5},
+    {true, "relevance", "relevance", 5},
+    {false, "relevance", "default", 4},
+    {false, "", "default", 4},
+    {true, "qs", "qs", 2},
+    {false, "the", "the", 2},
+    {false, "tuition_asc", "tuition_asc", 2},
+    {false, "tuition_desc", "tuition_desc", 2},
+    {false, "unknown", "default", 4},
+  }
+  for _, tt := range tests {
+    name, keys := filter{useFTS: tt.fts, amountSQL: "amount"}.sortKeys(tt.sort)
+    if name != tt.want || len(keys) != tt.keys {
+      t.Errorf("sortKeys(fts=%v, %q) = %s with %d keys, want %s with %d", tt.fts, tt.sort, name, len(keys), tt.want, tt.keys)
+    }
+    // id әрқашан соңғы тай-брейкер
+    if keys[len(keys)-1] != keyID { t.Errorf("sortKeys(%q): last key %v, want programs.id", tt.sort, keys[len(keys)-1]) }
+  }
+}
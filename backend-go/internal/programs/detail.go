@@ -0,0 +1,200 @@
+package programs
+
+import (
+  "context"
+  "errors"
+  "fmt"
+  "time"
+
+  "github.com/jackc/pgx/v5"
+
+  "unichance-backend-go/internal/admissions"
+  "unichance-backend-go/internal/universities"
+)
+
+// ProgramDetail — GET /programs/:id. Карточка өрістері + толық ақпарат.
+type ProgramDetail struct {
+  ProgramCard
+  Description *string `json:"description"`
+
+  University UniversitySummary `json:"university"`
+  Requirements *Requirements `json:"requirements"` // requirements жолы жоқ болса null
+  Deadlines []Deadline `json:"deadlines"`
+  Links []universities.UniversityLink `json:"links"`
+  Admissions *admissions.Summary `json:"admissions,omitempty"`
+
+  Provenance Provenance `json:"provenance"`
+  LatestScore *LatestScore `json:"latest_score,omitempty"` // тек авторизацияланған
+}
+
+type UniversitySummary struct {
+  ID string `json:"id"`
+  Name string `json:"name"`
+  CountryCode string `json:"country_code"`
+  City *string `json:"city,omitempty"`
+  Website *string `json:"website,omitempty"`
+  QSRank *int `json:"qs_rank,omitempty"`
+  THERank *int `json:"the_rank,omitempty"`
+}
+
+// Requirements — requirements кестесі (API көрінісі).
+type Requirements struct {
+  MinGPA *float64 `json:"min_gpa"`
+  GPASystem *string `json:"gpa_system"`
+  MinIELTS *float64 `json:"min_ielts"`
+  MinTOEFL *int `json:"min_toefl"`
+  MinDET *int `json:"min_duolingo"`
+  MinPTE *int `json:"min_pte"`
+  MinCambridge *int `json:"min_cambridge"`
+  MinSAT *int `json:"min_sat"`
+  MinACT *int `json:"min_act"`
+  MinGRE *int `json:"min_gre"`
+  MinGMAT *int `json:"min_gmat"`
+  MinUNT *int `json:"min_unt"`
+  Notes *string `json:"notes"`
+}
+
+type Deadline struct {
+  Intake string `json:"intake"`
+  Kind string `json:"kind"` // application | scholarship | documents | enrollment
+  Deadline time.Time `json:"deadline"`
+  Note *string `json:"note,omitempty"`
+  Passed bool `json:"passed"`
+  SourceCode *string `json:"source_code,omitempty"`
+}
+
+// Provenance — деректер қайдан және қашан келді.
+type Provenance struct {
+  DataSource *string `json:"data_source"`
+  DataUpdatedAt *time.Time `json:"data_updated_at"`
+  UpdatedAt time.Time `json:"updated_at"`
+  Sources []SourceRef `json:"sources"`
+}
+
+type SourceRef struct {
+  Code string `json:"code"`
+  Name string `json:"name"`
+  Kind string `json:"kind"`
+  URL *string `json:"url,omitempty"`
+  License *string `json:"license,omitempty"`
+  Reliability int `json:"reliability"`
+  LastFetchedAt *time.Time `json:"last_fetched_at,omitempty"`
+}
+
+// LatestScore — пайдаланушының осы бағдарлама бойынша соңғы /score нәтижесі.
+type LatestScore struct {
+  Score int `json:"score"`
+  Probability *int `json:"probability,omitempty"`
+  Category *string `json:"category,omitempty"`
+  Policy *string `json:"policy,omitempty"`
+  PolicyVersion *int `json:"policy_version,omitempty"`
+  CreatedAt time.Time `json:"created_at"`
+}
+
+// GetByID returns the full program; nil when it does not exist.
+func (r Repo) GetByID(ctx context.Context, id, displayCurrency string) (*ProgramDetail, error) {
+  f, err := r.buildFilter(ctx, ListParams{DisplayCurrency: displayCurrency})
+  if err != nil { return nil, err }
+  _, args := f.where("")
+  args = append(args, id)
+
+  var d ProgramDetail
+  dest := append(d.ProgramCard.dest(),
+    &d.Description, &d.Provenance.DataSource, &d.Provenance.DataUpdatedAt, &d.Provenance.UpdatedAt,
+    &d.University.Website,
+  )
+  err = r.DB.QueryRow(ctx, `
+    SELECT `+f.cardCols()+`,
+      programs.description, programs.data_source, programs.data_updated_at, programs.updated_at,
+      universities.website`+f.from()+`
+    WHERE programs.id = $`+fmt.Sprint(len(args)), args...).Scan(dest...)
+  if err != nil {
+    if errors.Is(err, pgx.ErrNoRows) { return nil, nil }
+    return nil, err
+  }
+  d.DisplayCurrency = f.display
+  d.University.ID, d.University.Name, d.University.CountryCode = d.UniversityID, d.UniversityName, d.CountryCode
+  d.University.City, d.University.QSRank, d.University.THERank = d.City, d.QSRank, d.THERank
+
+  if d.Requirements, err = r.requirements(ctx, id); err != nil { return nil, err }
+  if d.Deadlines, err = r.deadlines(ctx, id); err != nil { return nil, err }
+  if d.Links, err = (universities.Repo{DB: r.DB}).Links(ctx, d.UniversityID); err != nil { return nil, err }
+  if d.Provenance.Sources, err = r.sources(ctx, id, d.UniversityID, d.Provenance.DataSource); err != nil { return nil, err }
+  return &d, nil
+}
+
+func (r Repo) requirements(ctx context.Context, programID string) (*Requirements, error) {
+  var q Requirements
+  err := r.DB.QueryRow(ctx, `
+    SELECT min_gpa::float8, gpa_system, min_ielts::float8, min_toefl, min_duolingo, min_pte, min_cambridge,
+      min_sat, min_act, min_gre, min_gmat, min_unt, notes
+    FROM requirements WHERE program_id = $1
+  `, programID).Scan(
+    &q.MinGPA, &q.GPASystem, &q.MinIELTS, &q.MinTOEFL, &q.MinDET, &q.MinPTE, &q.MinCambridge,
+    &q.MinSAT, &q.MinACT, &q.MinGRE, &q.MinGMAT, &q.MinUNT, &q.Notes,
+  )
+  if errors.Is(err, pgx.ErrNoRows) { return nil, nil }
+  if err != nil { return nil, err }
+  return &q, nil
+}
+
+func (r Repo) deadlines(ctx context.Context, programID string) ([]Deadline, error) {
+  rows, err := r.DB.Query(ctx, `
+    SELECT d.intake, d.kind, d.deadline, d.note, d.deadline < CURRENT_DATE, s.code
+    FROM program_deadlines d
+    LEFT JOIN sources s ON s.id = d.source_id
+    WHERE d.program_id = $1
+    ORDER BY d.deadline ASC, d.kind ASC
+  `, programID)
+  if err != nil { return nil, err }
+  defer rows.Close()
+
+  out := []Deadline{}
+  for rows.Next() {
+    var d Deadline
+    if err := rows.Scan(&d.Intake, &d.Kind, &d.Deadline, &d.Note, &d.Passed, &d.SourceCode); err != nil { return nil, err }
+    out = append(out, d)
+  }
+  return out, rows.Err()
+}
+
+// sources lists every source behind the program's data: its data_source
+// code plus stats, deadlines and university links.
+func (r Repo) sources(ctx context.Context, programID, universityID string, dataSource *string) ([]SourceRef, error) {
+  rows, err := r.DB.Query(ctx, `
+    SELECT s.code, s.name, s.kind, s.base_url, s.license, s.reliability, s.last_fetched_at
+    FROM sources s
+    WHERE s.id IN (
+      SELECT source_id FROM admission_stats WHERE program_id = $1
+      UNION SELECT source_id FROM program_deadlines WHERE program_id = $1
+      UNION SELECT source_id FROM university_links WHERE university_id = $2
+    ) OR s.code = $3
+    ORDER BY s.reliability DESC, s.code ASC
+  `, programID, universityID, dataSource)
+  if err != nil { return nil, err }
+  defer rows.Close()
+
+  out := []SourceRef{}
+  for rows.Next() {
+    var s SourceRef
+    if err := rows.Scan(&s.Code, &s.Name, &s.Kind, &s.URL, &s.License, &s.Reliability, &s.LastFetchedAt); err != nil { return nil, err }
+    out = append(out, s)
+  }
+  return out, rows.Err()
+}
+
+// LatestScore returns the user's most recent score for the program, or nil.
+func (r Repo) LatestScore(ctx context.Context, userID, programID string) (*LatestScore, error) {
+  var s LatestScore
+  err := r.DB.QueryRow(ctx, `
+    SELECT s.score, s.probability, s.category, s.policy_name, s.policy_version, s.created_at
+    FROM scores s
+    JOIN profiles p ON p.id = s.profile_id
+    WHERE p.user_id = $1 AND s.program_id = $2
+    ORDER BY s.created_at DESC
+    LIMIT 1
+  `, userID, programID).Scan(&s.Score, &s.Probability, &s.Category, &s.Policy, &s.PolicyVersion, &s.CreatedAt)
+  if errors.Is(err, pgx.ErrNoRows) { return nil, nil }
+  if err != nil { return nil, err }
+  return &s, nil
+}
@@ -0,0 +1,69 @@
+package programs
+
+import (
+  "context"
+
+  "github.com/jackc/pgx/v5"
+)
+
+type FacetValue struct {
+  Value string `json:"value"`
+  Count int `json:"count"`
+}
+
+// Facets — facet кілті → мәндер (count DESC).
+type Facets map[string][]FacetValue
+
+// facetExprs — әр facet-тің GROUP BY өрнегі.
+var facetExprs = []struct{ key, expr string }{
+  {FacetCountry, "universities.country_code"},
+  {FacetLevel, "programs.degree_level::text"},
+  {FacetField, "programs.field"},
+  {FacetLanguage, "programs.language"},
+  {FacetCurrency, "programs.tuition_currency"},
+  {FacetScholarship, "programs.has_scholarship::text"},
+}
+
+// Facets counts programs per facet value under the same filters as List.
+// Each facet ignores its own filter, so selecting "DE" still shows how many
+// programs the other countries would add.
+func (r Repo) Facets(ctx context.Context, p ListParams) (Facets, error) {
+  f, err := r.buildFilter(ctx, p)
+  if err != nil { return nil, err }
+
+  b := &pgx.Batch{}
+  for _, fe := range facetExprs {
+    sql, args := f.facetQuery(fe.key, fe.expr)
+    b.Queue(sql, args...)
+  }
+
+  br := r.DB.SendBatch(ctx, b)
+  defer br.Close()
+
+  out := Facets{}
+  for _, fe := range facetExprs {
+    rows, err := br.Query()
+    if err != nil { return nil, err }
+    values := []FacetValue{}
+    for rows.Next() {
+      var v FacetValue
+      if err := rows.Scan(&v.Value, &v.Count); err != nil { rows.Close(); return nil, err }
+      values = append(values, v)
+    }
+    rows.Close()
+    if err := rows.Err(); err != nil { return nil, err }
+    out[fe.key] = values
+  }
+  return out, nil
+}
+
+// facetQuery — бір facet-тің сұрауы, оның өз фильтрінсіз.
+func (f filter) facetQuery(key, expr string) (string, []any) {
+  whereSQL, args := f.where(key)
+  return `
+      SELECT `+expr+`, COUNT(*)`+f.from()+`
+      WHERE `+whereSQL+` AND `+expr+` IS NOT NULL
+      GROUP BY 1
+      ORDER BY 2 DESC, 1 ASC
+    `, args
+}
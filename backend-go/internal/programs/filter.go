@@ -0,0 +1,107 @@
+package programs
+
+import (
+  "context"
+  "fmt"
+  "strings"
+
+  "unichance-backend-go/internal/currency"
+)
+
+// Facet keys — filter clause-тары осы кілттермен белгіленеді (disjunctive faceting).
+const (
+  FacetCountry = "country"
+  FacetLevel = "level"
+  FacetField = "field"
+  FacetLanguage = "language"
+  FacetCurrency = "currency"
+  FacetScholarship = "scholarship"
+)
+
+// searchQuery — q-дің көптілді tsquery-і синонимдермен (020_search_multilingual.sql);
+// subquery болғандықтан бір рет есептеледі.
+const searchQuery = "(SELECT programs_tsquery($1))"
+
+// searchRank — FTS рангі + қате терілген сұрауларға trigram ұқсастығы.
+const searchRank = "(ts_rank(programs.search_vector, " + searchQuery + ") + word_similarity($1, programs.search_text))"
+
+// clause — бір WHERE шарты; sql ішінде мәнге арналған бір $%d бар.
+type clause struct {
+  facet string // бос болса ешқашан алынып тасталмайды
+  sql string
+  val any
+}
+
+// filter — List, Facets ортақ қолданатын WHERE құрастырушы.
+// base args (q, бағамдар) әрқашан бірінші тұрады, сондықтан fxJoin
+// ішіндегі $N нөмірлері кез келген clause жиынында өзгермейді. Барлық бағам
+// args fxJoin ішінде қолданылады: from() бар әр сұрау оларды түгел байланыстырады,
+// ал amountSQL-де $N жоқ (COUNT, facets оны қолданбауы мүмкін).
+type filter struct {
+  base []any
+  clauses []clause
+  useFTS bool
+  display string
+  fxJoin string
+  amountSQL string
+}
+
+func (r Repo) buildFilter(ctx context.Context, p ListParams) (filter, error) {
+  var f filter
+
+  // q (FTS + синонимдер + trigram) — әрқашан $1
+  f.useFTS = strings.TrimSpace(p.Q) != ""
+  if f.useFTS {
+    f.base = append(f.base, strings.TrimSpace(p.Q))
+    f.clauses = append(f.clauses, clause{sql: "(programs.search_vector @@ " + searchQuery + " OR $1 <% programs.search_text)"})
+  }
+
+  // tuition → display currency (бағамдар SQL-ге массив ретінде беріледі)
+  f.display = strings.ToUpper(strings.TrimSpace(p.DisplayCurrency))
+  if f.display == "" { f.display = currency.Default }
+  rates := r.FX.Current(ctx)
+  if !rates.Has(f.display) { return filter{}, fmt.Errorf("unknown display currency: %s", f.display) }
+  codes, perBase := rates.Codes()
+  target, _ := rates.Convert(1, rates.Base, f.display)
+  f.base = append(f.base, codes, perBase, target)
+  n := len(f.base)
+  // per_display = per_base / target: display валютасының бір бірлігіне келетін тариф валютасы
+  f.fxJoin = fmt.Sprintf(`LEFT JOIN (
+      SELECT code, per_base, per_base / $%d::float8 AS per_display
+      FROM unnest($%d::text[], $%d::float8[]) AS u(code, per_base)
+    ) AS fx ON fx.code = programs.tuition_currency`, n, n-2, n-1)
+  f.amountSQL = "(programs.tuition_amount::float8 / fx.per_display)"
+
+  add := func(facet, sql string, val any) { f.clauses = append(f.clauses, clause{facet, sql, val}) }
+  if len(p.Countries) > 0 { add(FacetCountry, "universities.country_code = ANY($%d)", p.Countries) }
+  if len(p.Levels) > 0 { add(FacetLevel, "programs.degree_level::text = ANY($%d)", p.Levels) }
+  if len(p.Fields) > 0 { add(FacetField, "programs.field = ANY($%d)", p.Fields) }
+  if len(p.Languages) > 0 { add(FacetLanguage, "programs.language = ANY($%d)", p.Languages) }
+  if p.Currency != "" { add(FacetCurrency, "programs.tuition_currency = $%d", currency.Normalize(p.Currency)) }
+  if p.MinTuition != nil { add("", f.amountSQL+" >= $%d", *p.MinTuition) }
+  if p.MaxTuition != nil { add("", f.amountSQL+" <= $%d", *p.MaxTuition) }
+  if p.Scholarship != nil { add(FacetScholarship, "programs.has_scholarship = $%d", *p.Scholarship) }
+  return f, nil
+}
+
+// where renders the WHERE body and its args, skipping the clauses of the
+// exclude facet ("" keeps everything).
+func (f filter) where(exclude string) (string, []any) {
+  args := append([]any{}, f.base...)
+  where := []string{"1=1"}
+  for _, c := range f.clauses {
+    if exclude != "" && c.facet == exclude { continue }
+    if c.val == nil { where = append(where, c.sql); continue }
+    args = append(args, c.val)
+    where = append(where, fmt.Sprintf(c.sql, len(args)))
+  }
+  return strings.Join(where, " AND "), args
+}
+
+// from — FROM + JOIN бөлігі (fx join amountSQL үшін керек).
+func (f filter) from() string {
+  return `
+    FROM programs
+    JOIN universities ON universities.id = programs.university_id
+    ` + f.fxJoin
+}
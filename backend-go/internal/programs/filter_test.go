@@ -0,0 +1,68 @@
+package programs
+
+import (
+  "context"
+  "regexp"
+  "strconv"
+  "testing"
+
+  "unichance-backend-go/internal/keyset"
+)
+
+var placeholderRe = regexp.MustCompile(`\$(\d+)`)
+
+// checkArgs fails unless the SQL uses exactly $1..$len(args): pgx rejects
+// unused args, Postgres rejects gaps.
+func checkArgs(t *testing.T, name, sql string, args []any) {
+  t.Helper()
+  used := map[int]bool{}
+  for _, m := range placeholderRe.FindAllStringSubmatch(sql, -1) {
+    n, _ := strconv.Atoi(m[1])
+    used[n] = true
+  }
+  if len(used) != len(args) { t.Errorf("%s: %d placeholders for %d args\n%s", name, len(used), len(args), sql) }
+  for i := 1; i <= len(args); i++ {
+    if !used[i] { t.Errorf("%s: $%d is not used", name, i) }
+  }
+}
+
+func TestFilterArgs(t *testing.T) {
+  amount := 10000.0
+  tests := []struct {
+    name string
+    p ListParams
+  }{
+    {"no filter", ListParams{}},
+    {"min only", ListParams{MinTuition: &amount}},
+    {"max only", ListParams{MaxTuition: &amount}},
+    {"min and max in display currency", ListParams{MinTuition: &amount, MaxTuition: &amount, DisplayCurrency: "kzt"}},
+    {"q with facets", ListParams{Q: "data", Countries: []string{"DE"}, Levels: []string{"master"}, Scholarship: new(bool)}},
+    {"tuition sort", ListParams{Sort: "tuition_asc"}},
+  }
+  for _, tt := range tests {
+    f, err := (Repo{}).buildFilter(context.Background(), tt.p)
+    if err != nil { t.Fatalf("%s: %v", tt.name, err) }
+
+    sql, args := f.countQuery()
+    checkArgs(t, tt.name+" count", sql, args)
+
+    sortName, keys := f.sortKeys(tt.p.Sort)
+    sql, args = f.itemsQuery(keys, keyset.Cursor{}, 20, 0)
+    checkArgs(t, tt.name+" items", sql, args)
+
+    cur := keyset.Cursor{Sort: sortName, Keys: make([]any, len(keys)), Back: true}
+    sql, args = f.itemsQuery(keys, cur, 20, 0)
+    checkArgs(t, tt.name+" items after cursor", sql, args)
+
+    for _, fe := range facetExprs {
+      sql, args = f.facetQuery(fe.key, fe.expr)
+      checkArgs(t, tt.name+" facet "+fe.key, sql, args)
+    }
+  }
+}
+
+func TestBuildFilterUnknownCurrency(t *testing.T) {
+  if _, err := (Repo{}).buildFilter(context.Background(), ListParams{DisplayCurrency: "XXX"}); err == nil {
+    t.Error("buildFilter accepted an unknown display currency")
+  }
+}
@@ -1,14 +1,31 @@
 package programs
 
 import (
+  "context"
   "net/http"
+  "regexp"
   "strconv"
   "strings"
 
   "github.com/labstack/echo/v4"
+
+  "unichance-backend-go/internal/admissions"
+  "unichance-backend-go/internal/middleware"
 )
 
-type Handler struct { Repo Repo }
+// Scorer scores programs for a user (implemented by profile.Handler).
+// Programs without a score are simply missing from the map.
+type Scorer interface {
+  ScorePrograms(ctx context.Context, userID string, programIDs []string) (map[string]CardScore, error)
+  // ScoreDetails also returns the localized requirement gaps (GET /programs/compare).
+  ScoreDetails(ctx context.Context, userID, acceptLanguage string, programIDs []string) (map[string]ScoreDetail, error)
+}
+
+type Handler struct {
+  Repo Repo
+  Admissions admissions.Repo
+  Scorer Scorer // nil болса ұпай енгізілмейді
+}
 
 func splitCSV(s string) []string {
   if strings.TrimSpace(s) == "" { return nil }
@@ -43,22 +60,103 @@ func (h Handler) List(c echo.Context) error {
     Countries: splitCSV(c.QueryParam("countries")),
     Levels: splitCSV(c.QueryParam("levels")),
     Fields: splitCSV(c.QueryParam("fields")),
+    Languages: splitCSV(c.QueryParam("languages")),
     Currency: strings.TrimSpace(c.QueryParam("currency")),
+    DisplayCurrency: c.QueryParam("display_currency"),
     MinTuition: minT,
     MaxTuition: maxT,
     Scholarship: sch,
     Sort: c.QueryParam("sort"),
     Page: page,
     Limit: limit,
+    Cursor: c.QueryParam("cursor"),
+    SkipTotal: c.QueryParam("total") == "false",
   }
 
-  items, total, err := h.Repo.List(c.Request().Context(), params)
+  res, err := h.Repo.List(c.Request().Context(), params)
   if err != nil { return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()}) }
+  items := res.Items
+  if items == nil { items = []ProgramCard{} }
 
-  return c.JSON(http.StatusOK, map[string]any{
+  if c.QueryParam("with_scores") == "true" {
+    if err := h.embedScores(c, items); err != nil {
+      return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+    }
+  }
+
+  resp := map[string]any{
     "page": params.Page,
     "limit": params.Limit,
-    "total": total,
     "items": items,
+    "next_cursor": res.NextCursor,
+    "prev_cursor": res.PrevCursor,
+  }
+  if res.Total != nil { resp["total"] = *res.Total }
+  // sidebar санаулары қымбат: тек facets=true сұралғанда
+  if c.QueryParam("facets") == "true" {
+    facets, err := h.Repo.Facets(c.Request().Context(), params)
+    if err != nil { return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()}) }
+    resp["facets"] = facets
+  }
+  return c.JSON(http.StatusOK, resp)
+}
+
+var uuidRe = regexp.MustCompile(`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`)
+
+// badID — :id UUID болмаса Postgres 22P02 береді, оны 500 емес 400 ретінде қайтарамыз.
+func badID(c echo.Context) error {
+  return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid program id"})
+}
+
+// Get — GET /programs/:id. Авторизацияланған пайдаланушыға соңғы ұпайы қосылады.
+func (h Handler) Get(c echo.Context) error {
+  ctx := c.Request().Context()
+  if !uuidRe.MatchString(c.Param("id")) { return badID(c) }
+  p, err := h.Repo.GetByID(ctx, c.Param("id"), c.QueryParam("display_currency"))
+  if err != nil { return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()}) }
+  if p == nil { return c.JSON(http.StatusNotFound, map[string]string{"error": "program not found"}) }
+
+  if p.Admissions, err = h.Admissions.Summary(ctx, p.ID); err != nil {
+    return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+  }
+  if u, ok := middleware.UserFrom(c); ok {
+    if p.LatestScore, err = h.Repo.LatestScore(ctx, u.ID, p.ID); err != nil {
+      return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+    }
+  }
+  return c.JSON(http.StatusOK, p)
+}
+
+func (h Handler) AdmissionStats(c echo.Context) error {
+  id := c.Param("id")
+  years, err := h.Admissions.ListByProgram(c.Request().Context(), id)
+  if err != nil { return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()}) }
+  summary, err := h.Admissions.Summary(c.Request().Context(), id)
+  if err != nil { return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()}) }
+
+  return c.JSON(http.StatusOK, map[string]any{
+    "program_id": id,
+    "summary": summary,
+    "years": years,
   })
 }
+
+// embedScores attaches the caller's scores to cards; anonymous callers
+// (or no Scorer) get the list unchanged.
+func (h Handler) embedScores(c echo.Context, items []ProgramCard) error {
+  u, ok := middleware.UserFrom(c)
+  if !ok || h.Scorer == nil || len(items) == 0 { return nil }
+
+  ids := make([]string, len(items))
+  for i, it := range items { ids[i] = it.ID }
+  scores, err := h.Scorer.ScorePrograms(c.Request().Context(), u.ID, ids)
+  if err != nil { return err }
+
+  for i := range items {
+    if sc, ok := scores[items[i].ID]; ok {
+      sc := sc
+      items[i].Score = &sc
+    }
+  }
+  return nil
+}
@@ -10,6 +10,10 @@ type ProgramCard struct {
 	TuitionAmount   *float64 `json:"tuition_amount"`
 	TuitionCurrency *string  `json:"tuition_currency"`
 
+	// tuition_amount display currency-де (бағам белгісіз болса null)
+	DisplayAmount   *float64 `json:"display_amount"`
+	DisplayCurrency string   `json:"display_currency"`
+
 	HasScholarship        bool    `json:"has_scholarship"`
 	ScholarshipType       *string `json:"scholarship_type"`
 	ScholarshipPercentMin *int    `json:"scholarship_percent_min"`
@@ -22,4 +26,16 @@ type ProgramCard struct {
 	THERank        *int    `json:"the_rank"`
 
 	UniversityID string `json:"university_id"`
+
+	// тек авторизацияланған және with_scores=true болғанда
+	Score *CardScore `json:"score,omitempty"`
+}
+
+// CardScore — карточкаға енгізілетін қысқа ұпай.
+type CardScore struct {
+	Score       int    `json:"score"`
+	Probability *int   `json:"probability,omitempty"`
+	Category    string `json:"category,omitempty"`
+
+	Affordability string `json:"affordability,omitempty"` // scoring.AffordabilityStatus
 }
@@ -6,127 +6,146 @@ import (
   "strings"
 
   "github.com/jackc/pgx/v5/pgxpool"
+
+  "unichance-backend-go/internal/currency"
+  "unichance-backend-go/internal/keyset"
 )
 
-type Repo struct { DB *pgxpool.Pool }
+type Repo struct {
+  DB *pgxpool.Pool
+  FX *currency.Service // nil болса currency.Fallback
+}
 
 type ListParams struct {
-  Q string
-  Countries []string
-  Levels []string
-  Fields []string
-  Currency string
-  MinTuition *float64
-  MaxTuition *float64
-  Scholarship *bool
-  Sort string
-  Page int
-  Limit int
+  Q string `json:"q"`
+  Countries []string `json:"countries"`
+  Levels []string `json:"levels"`
+  Fields []string `json:"fields"`
+  Languages []string `json:"languages"`
+  Currency string `json:"currency"`
+  DisplayCurrency string `json:"display_currency"` // min/max_tuition, tuition сұрыптау және display_amount осы валютада
+  MinTuition *float64 `json:"min_tuition"`
+  MaxTuition *float64 `json:"max_tuition"`
+  Scholarship *bool `json:"scholarship"`
+  Sort string `json:"sort"`
+  Page int `json:"page"` // cursor жоқ кезде ғана (OFFSET)
+  Limit int `json:"limit"`
+  Cursor string `json:"cursor"` // алдыңғы жауаптың next_cursor/prev_cursor
+  SkipTotal bool `json:"skip_total"` // COUNT(*) есептелмейді
+}
+
+// ListResult — бір бет. Total SkipTotal кезінде nil.
+type ListResult struct {
+  Items []ProgramCard
+  Total *int
+  NextCursor string
+  PrevCursor string
 }
 
-func (r Repo) List(ctx context.Context, p ListParams) (items []ProgramCard, total int, err error) {
+// List pages through programs with keyset cursors over the active sort keys.
+// Without a cursor, Page > 1 still falls back to OFFSET for old clients.
+func (r Repo) List(ctx context.Context, p ListParams) (ListResult, error) {
   if p.Page <= 0 { p.Page = 1 }
   if p.Limit <= 0 { p.Limit = 20 }
   if p.Limit > 50 { p.Limit = 50 }
 
-  where := []string{"1=1"}
-  args := []any{}
-  add := func(cond string, val any) { args = append(args, val); where = append(where, fmt.Sprintf(cond, len(args))) }
+  f, err := r.buildFilter(ctx, p)
+  if err != nil { return ListResult{}, err }
 
-  // q (FTS)
-  useFTS := strings.TrimSpace(p.Q) != ""
-  if useFTS {
-    add("programs.search_vector @@ plainto_tsquery('simple', $%d)", p.Q)
+  var res ListResult
+  if !p.SkipTotal {
+    var total int
+    countSQL, args := f.countQuery()
+    if err := r.DB.QueryRow(ctx, countSQL, args...).Scan(&total); err != nil {
+      return ListResult{}, err
+    }
+    res.Total = &total
   }
 
-  if len(p.Countries) > 0 {
-    args = append(args, p.Countries)
-    where = append(where, fmt.Sprintf("universities.country_code = ANY($%d)", len(args)))
-  }
-  if len(p.Levels) > 0 {
-    args = append(args, p.Levels)
-    where = append(where, fmt.Sprintf("programs.degree_level::text = ANY($%d)", len(args)))
-  }
-  if len(p.Fields) > 0 {
-    args = append(args, p.Fields)
-    where = append(where, fmt.Sprintf("programs.field = ANY($%d)", len(args)))
-  }
-  if p.Currency != "" {
-    add("programs.tuition_currency::text = $%d", p.Currency)
-  }
-  if p.MinTuition != nil {
-    add("programs.tuition_amount >= $%d", *p.MinTuition)
-  }
-  if p.MaxTuition != nil {
-    add("programs.tuition_amount <= $%d", *p.MaxTuition)
-  }
-  if p.Scholarship != nil {
-    add("programs.has_scholarship = $%d", *p.Scholarship)
+  // sort + keyset
+  sortName, keys := f.sortKeys(p.Sort)
+  fhash := filterHash(p)
+  var cur keyset.Cursor
+  offset := 0
+  if p.Cursor != "" {
+    cur, err = keyset.Decode(p.Cursor, sortName, fhash, keys)
+    if err != nil { return ListResult{}, err }
+  } else {
+    offset = (p.Page - 1) * p.Limit
   }
 
-  whereSQL := strings.Join(where, " AND ")
+  itemsSQL, args := f.itemsQuery(keys, cur, p.Limit, offset)
+  rows, err := r.DB.Query(ctx, itemsSQL, args...)
+  if err != nil { return ListResult{}, err }
+  defer rows.Close()
 
-  // sort
-  orderSQL := "universities.qs_rank ASC NULLS LAST, universities.the_rank ASC NULLS LAST, programs.title ASC"
-  if useFTS && (p.Sort == "" || p.Sort == "relevance") {
-    orderSQL = "ts_rank(programs.search_vector, plainto_tsquery('simple', $1)) DESC, " + orderSQL
-  } else {
-    switch p.Sort {
-    case "tuition_asc":
-      orderSQL = "programs.tuition_amount ASC NULLS LAST"
-    case "tuition_desc":
-      orderSQL = "programs.tuition_amount DESC NULLS LAST"
-    case "qs":
-      orderSQL = "universities.qs_rank ASC NULLS LAST"
-    case "the":
-      orderSQL = "universities.the_rank ASC NULLS LAST"
-    }
+  var rowKeys [][]any
+  for rows.Next() {
+    var it ProgramCard
+    kd := keyset.Dest(keys)
+    dest := append(it.dest(), kd...)
+    if err := rows.Scan(dest...); err != nil { return ListResult{}, err }
+    it.DisplayCurrency = f.display
+    res.Items = append(res.Items, it)
+    rowKeys = append(rowKeys, keyset.Values(kd))
+  }
+  if err := rows.Err(); err != nil { return ListResult{}, err }
+
+  more := len(res.Items) > p.Limit
+  if more {
+    res.Items, rowKeys = res.Items[:p.Limit], rowKeys[:p.Limit]
   }
+  if cur.Back {
+    keyset.Reverse(res.Items)
+    keyset.Reverse(rowKeys)
+  }
+  res.NextCursor, res.PrevCursor = keyset.Links(cur, sortName, fhash, rowKeys, more, p.Cursor != "" || offset > 0)
+  return res, nil
+}
 
-  // countQuery
-  countSQL := `
-    SELECT COUNT(*)
-    FROM programs
-    JOIN universities ON universities.id = programs.university_id
-    WHERE ` + whereSQL
+// countQuery — List-тің COUNT(*) сұрауы.
+func (f filter) countQuery() (string, []any) {
+  whereSQL, args := f.where("")
+  return `
+    SELECT COUNT(*)` + f.from() + `
+    WHERE ` + whereSQL, args
+}
 
-  if err := r.DB.QueryRow(ctx, countSQL, args...).Scan(&total); err != nil {
-    return nil, 0, err
+// itemsQuery — бір беттің сұрауы. cur.Keys бос болмаса keyset шарты
+// қосылады, әйтпесе offset қолданылады.
+func (f filter) itemsQuery(keys []keyset.Key, cur keyset.Cursor, limit, offset int) (string, []any) {
+  whereSQL, args := f.where("")
+  if len(cur.Keys) > 0 {
+    whereSQL += " AND " + keyset.Where(keys, cur.Back, len(args)+1)
+    args = append(args, cur.Keys...)
   }
 
-  // itemsQuery
-  offset := (p.Page - 1) * p.Limit
-  args = append(args, p.Limit, offset)
-  limitPos := len(args) - 1
-  offsetPos := len(args)
+  // бір артық жол келесі (кері бағытта — алдыңғы) беттің барын көрсетеді
+  args = append(args, limit+1, offset)
+  return `
+    SELECT ` + f.cardCols() + `,
+      ` + strings.Join(keyset.Exprs(keys), ", ") + f.from() + `
+    WHERE ` + whereSQL + `
+    ORDER BY ` + keyset.Order(keys, cur.Back) + `
+    LIMIT $` + fmt.Sprint(len(args)-1) + ` OFFSET $` + fmt.Sprint(len(args)), args
+}
 
-  itemsSQL := `
-    SELECT
+// cardCols — ProgramCard бағандары, (*ProgramCard).dest ретімен.
+func (f filter) cardCols() string {
+  return `
       programs.id, programs.title, programs.degree_level::text, programs.field, programs.language,
-      programs.tuition_amount, programs.tuition_currency::text,
+      programs.tuition_amount, programs.tuition_currency,
+      round(` + f.amountSQL + `::numeric, 2)::float8,
       programs.has_scholarship, programs.scholarship_type, programs.scholarship_percent_min, programs.scholarship_percent_max,
-      universities.name, universities.country_code, universities.city, universities.qs_rank, universities.the_rank, 
-      programs.university_id
-    FROM programs
-    JOIN universities ON universities.id = programs.university_id
-    WHERE ` + whereSQL + `
-    ORDER BY ` + orderSQL + `
-    LIMIT $` + fmt.Sprint(limitPos) + ` OFFSET $` + fmt.Sprint(offsetPos)
-
-  rows, err := r.DB.Query(ctx, itemsSQL, args...)
-  if err != nil { return nil, 0, err }
-  defer rows.Close()
+      universities.name, universities.country_code, universities.city, universities.qs_rank, universities.the_rank,
+      programs.university_id`
+}
 
-  for rows.Next() {
-    var it ProgramCard
-    err := rows.Scan(
-      &it.ID, &it.Title, &it.DegreeLevel, &it.Field, &it.Language,
-      &it.TuitionAmount, &it.TuitionCurrency,
-      &it.HasScholarship, &it.ScholarshipType, &it.ScholarshipPercentMin, &it.ScholarshipPercentMax,
-      &it.UniversityName, &it.CountryCode, &it.City, &it.QSRank, &it.THERank, &it.UniversityID,
-    )
-    if err != nil { return nil, 0, err }
-    items = append(items, it)
+func (it *ProgramCard) dest() []any {
+  return []any{
+    &it.ID, &it.Title, &it.DegreeLevel, &it.Field, &it.Language,
+    &it.TuitionAmount, &it.TuitionCurrency, &it.DisplayAmount,
+    &it.HasScholarship, &it.ScholarshipType, &it.ScholarshipPercentMin, &it.ScholarshipPercentMax,
+    &it.UniversityName, &it.CountryCode, &it.City, &it.QSRank, &it.THERank, &it.UniversityID,
   }
-  return items, total, rows.Err()
 }
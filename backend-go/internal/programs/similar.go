@@ -0,0 +1,163 @@
+package programs
+
+import (
+  "context"
+  "errors"
+  "fmt"
+  "math"
+  "net/http"
+  "strconv"
+
+  "github.com/jackc/pgx/v5"
+  "github.com/labstack/echo/v4"
+)
+
+const (
+  DefaultSimilar = 10
+  MaxSimilar = 30
+)
+
+// Similarity features.
+const (
+  SimField = "field"
+  SimLevel = "degree_level"
+  SimLanguage = "language"
+  SimTuition = "tuition"
+  SimRank = "rank_tier"
+)
+
+// SimilarityWeights — белгілер салмағы (қосындысы 1).
+var SimilarityWeights = map[string]float64{
+  SimField: 0.35,
+  SimLevel: 0.20,
+  SimLanguage: 0.15,
+  SimTuition: 0.15,
+  SimRank: 0.15,
+}
+
+// similarFeatures — SELECT/Scan реті.
+var similarFeatures = []string{SimField, SimLevel, SimLanguage, SimTuition, SimRank}
+
+// SimilarityFeature — бір белгінің түсіндірмесі: Value 0..1, Contribution = Weight*Value.
+type SimilarityFeature struct {
+  Name string `json:"name"`
+  Value float64 `json:"value"`
+  Weight float64 `json:"weight"`
+  Contribution float64 `json:"contribution"`
+  Known bool `json:"known"` // false: бір жақта дерек жоқ (мысалы tuition белгісіз)
+}
+
+type SimilarProgram struct {
+  ProgramCard
+  Similarity int `json:"similarity"` // 0..100
+  Features []SimilarityFeature `json:"features"`
+}
+
+// Similar returns the programs most like programID. Features:
+//   - field: same field 1, synonym field/title (search_synonyms) 0.8, else trigram similarity;
+//   - degree level and language: exact match;
+//   - tuition: log-ratio of yearly tuition in one currency (4x apart → 0);
+//   - rank tier: QS 1–50/51–100/101–200/201–500/500+/unranked, adjacent tier 0.5.
+// nil means the source program does not exist.
+func (r Repo) Similar(ctx context.Context, programID, displayCurrency string, limit int) ([]SimilarProgram, error) {
+  if limit <= 0 { limit = DefaultSimilar }
+  if limit > MaxSimilar { limit = MaxSimilar }
+
+  var exists bool
+  if err := r.DB.QueryRow(ctx, `SELECT true FROM programs WHERE id = $1`, programID).Scan(&exists); err != nil {
+    if errors.Is(err, pgx.ErrNoRows) { return nil, nil }
+    return nil, err
+  }
+
+  f, err := r.buildFilter(ctx, ListParams{DisplayCurrency: displayCurrency})
+  if err != nil { return nil, err }
+  _, args := f.where("")
+  args = append(args, programID, limit)
+  srcPos, limitPos := len(args)-1, len(args)
+
+  score := "0"
+  for _, name := range similarFeatures {
+    score += fmt.Sprintf(" + %g * COALESCE(feat.f_%s, 0)", SimilarityWeights[name], name)
+  }
+
+  // base currency бірлігінде; +1000 тегін және арзан бағдарламаларды жақындатады
+  const amt = "(programs.tuition_amount::float8 / fx.per_base)"
+  const tier = `(CASE WHEN universities.qs_rank IS NULL THEN 5 WHEN universities.qs_rank <= 50 THEN 0
+    WHEN universities.qs_rank <= 100 THEN 1 WHEN universities.qs_rank <= 200 THEN 2
+    WHEN universities.qs_rank <= 500 THEN 3 ELSE 4 END)`
+
+  rows, err := r.DB.Query(ctx, `
+    WITH src AS (
+      SELECT programs.id, programs.title, programs.field, programs.degree_level, programs.language,
+        `+amt+` AS amt, `+tier+` AS tier`+f.from()+`
+      WHERE programs.id = $`+fmt.Sprint(srcPos)+`
+    ),
+    feat AS (
+      SELECT programs.id AS pid,
+        CASE
+          WHEN lower(programs.field) = lower(src.field) THEN 1.0
+          WHEN EXISTS (
+            SELECT 1 FROM search_synonyms s1
+            JOIN search_synonyms s2 ON s2.group_key = s1.group_key
+            WHERE lower(s1.term) IN (lower(src.field), lower(src.title))
+              AND lower(s2.term) IN (lower(programs.field), lower(programs.title))
+          ) THEN 0.8
+          ELSE GREATEST(similarity(programs.field, src.field), similarity(programs.title, src.title))::float8
+        END::float8 AS f_field,
+        (programs.degree_level = src.degree_level)::int::float8 AS f_degree_level,
+        (lower(programs.language) = lower(src.language))::int::float8 AS f_language,
+        CASE WHEN `+amt+` IS NULL OR src.amt IS NULL THEN NULL
+          ELSE GREATEST(0, 1 - abs(ln((`+amt+` + 1000) / (src.amt + 1000))) / ln(4))
+        END AS f_tuition,
+        CASE WHEN `+tier+` = src.tier THEN 1.0
+          WHEN `+tier+` < 5 AND src.tier < 5 AND abs(`+tier+` - src.tier) = 1 THEN 0.5
+          ELSE 0.0
+        END::float8 AS f_rank_tier`+f.from()+`
+      CROSS JOIN src
+      WHERE programs.id <> src.id
+    )
+    SELECT `+f.cardCols()+`,
+      feat.f_field, feat.f_degree_level, feat.f_language, feat.f_tuition, feat.f_rank_tier`+f.from()+`
+    JOIN feat ON feat.pid = programs.id
+    ORDER BY `+score+` DESC, universities.qs_rank ASC NULLS LAST, programs.id ASC
+    LIMIT $`+fmt.Sprint(limitPos), args...)
+  if err != nil { return nil, err }
+  defer rows.Close()
+
+  out := []SimilarProgram{}
+  for rows.Next() {
+    var sp SimilarProgram
+    vals := make([]*float64, len(similarFeatures))
+    dest := sp.ProgramCard.dest()
+    for i := range vals { dest = append(dest, &vals[i]) }
+    if err := rows.Scan(dest...); err != nil { return nil, err }
+    sp.DisplayCurrency = f.display
+
+    total := 0.0
+    for i, name := range similarFeatures {
+      ft := SimilarityFeature{Name: name, Weight: SimilarityWeights[name]}
+      if vals[i] != nil {
+        ft.Known = true
+        ft.Value = round2(*vals[i])
+        ft.Contribution = round2(ft.Weight * *vals[i])
+        total += ft.Weight * *vals[i]
+      }
+      sp.Features = append(sp.Features, ft)
+    }
+    sp.Similarity = int(math.Round(total * 100))
+    out = append(out, sp)
+  }
+  return out, rows.Err()
+}
+
+// SimilarPrograms — GET /programs/:id/similar?limit=10&display_currency=EUR
+func (h Handler) SimilarPrograms(c echo.Context) error {
+  if !uuidRe.MatchString(c.Param("id")) { return badID(c) }
+  limit, _ := strconv.Atoi(c.QueryParam("limit"))
+  items, err := h.Repo.Similar(c.Request().Context(), c.Param("id"), c.QueryParam("display_currency"), limit)
+  if err != nil { return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()}) }
+  if items == nil { return c.JSON(http.StatusNotFound, map[string]string{"error": "program not found"}) }
+  return c.JSON(http.StatusOK, map[string]any{"program_id": c.Param("id"), "items": items})
+}
+
+func round2(v float64) float64 { return math.Round(v*100) / 100 }
@@ -0,0 +1,46 @@
+package ratelimit
+
+import (
+	"context"
+	"time"
+)
+
+// Lockout locks a key (account) after Threshold failures within Window.
+// Each further failure doubles the lock, starting at Base, capped at Max.
+type Lockout struct {
+	Store     Store
+	Threshold int
+	Window    time.Duration
+	Base      time.Duration
+	Max       time.Duration
+}
+
+// DefaultLockout: 5 қате → 30s, одан кейін 1m, 2m ... 1h дейін.
+func DefaultLockout(store Store) Lockout {
+	return Lockout{Store: store, Threshold: 5, Window: 15 * time.Minute, Base: 30 * time.Second, Max: time.Hour}
+}
+
+func (l Lockout) LockedFor(ctx context.Context, key string) (time.Duration, error) {
+	return l.Store.LockedFor(ctx, "lock:"+key)
+}
+
+// Failure records a failed attempt and returns the failure count and the
+// lock it caused (0 while under the threshold).
+func (l Lockout) Failure(ctx context.Context, key string) (int, time.Duration, error) {
+	n, err := l.Store.Fail(ctx, "lock:"+key, l.Window)
+	if err != nil || n < l.Threshold {
+		return n, 0, err
+	}
+	d := l.Base
+	for i := l.Threshold; i < n && d < l.Max; i++ {
+		d *= 2
+	}
+	if d > l.Max {
+		d = l.Max
+	}
+	return n, d, l.Store.Lock(ctx, "lock:"+key, d)
+}
+
+func (l Lockout) Success(ctx context.Context, key string) error {
+	return l.Store.Reset(ctx, "lock:"+key)
+}
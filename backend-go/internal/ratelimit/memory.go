@@ -0,0 +1,126 @@
+package ratelimit
+
+import (
+	"context"
+	"math"
+	"sync"
+	"time"
+)
+
+type bucket struct {
+	tokens float64
+	last   time.Time
+	rate   float64
+	burst  float64
+}
+
+type failures struct {
+	count       int
+	last        time.Time
+	window      time.Duration
+	lockedUntil time.Time
+}
+
+// MemoryStore is an in-process Store; idle entries are swept lazily.
+type MemoryStore struct {
+	mu        sync.Mutex
+	buckets   map[string]*bucket
+	failures  map[string]*failures
+	lastSweep time.Time
+	now       func() time.Time
+}
+
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{
+		buckets:  map[string]*bucket{},
+		failures: map[string]*failures{},
+		now:      time.Now,
+	}
+}
+
+func (s *MemoryStore) Take(ctx context.Context, key string, l Limit) (Result, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	now := s.now()
+	s.sweep(now)
+
+	b := s.buckets[key]
+	if b == nil {
+		b = &bucket{tokens: float64(l.Burst), last: now}
+		s.buckets[key] = b
+	}
+	b.rate, b.burst = l.Rate, float64(l.Burst)
+	b.tokens = math.Min(b.burst, b.tokens+now.Sub(b.last).Seconds()*b.rate)
+	b.last = now
+
+	if b.tokens < 1 {
+		wait := time.Duration((1 - b.tokens) / b.rate * float64(time.Second))
+		return Result{Allowed: false, RetryAfter: wait}, nil
+	}
+	b.tokens--
+	return Result{Allowed: true, Remaining: int(b.tokens)}, nil
+}
+
+func (s *MemoryStore) Fail(ctx context.Context, key string, window time.Duration) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	now := s.now()
+
+	f := s.failures[key]
+	if f == nil || (now.Sub(f.last) > f.window && !now.Before(f.lockedUntil)) {
+		f = &failures{}
+		s.failures[key] = f
+	}
+	f.count++
+	f.last = now
+	f.window = window
+	return f.count, nil
+}
+
+func (s *MemoryStore) Reset(ctx context.Context, key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.failures, key)
+	return nil
+}
+
+func (s *MemoryStore) Lock(ctx context.Context, key string, d time.Duration) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	f := s.failures[key]
+	if f == nil {
+		f = &failures{last: s.now()}
+		s.failures[key] = f
+	}
+	f.lockedUntil = s.now().Add(d)
+	return nil
+}
+
+func (s *MemoryStore) LockedFor(ctx context.Context, key string) (time.Duration, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if f := s.failures[key]; f != nil {
+		if d := f.lockedUntil.Sub(s.now()); d > 0 {
+			return d, nil
+		}
+	}
+	return 0, nil
+}
+
+// sweep drops full buckets and expired counters at most once a minute.
+func (s *MemoryStore) sweep(now time.Time) {
+	if now.Sub(s.lastSweep) < time.Minute {
+		return
+	}
+	s.lastSweep = now
+	for k, b := range s.buckets {
+		if b.tokens+now.Sub(b.last).Seconds()*b.rate >= b.burst {
+			delete(s.buckets, k)
+		}
+	}
+	for k, f := range s.failures {
+		if now.Sub(f.last) > f.window && !now.Before(f.lockedUntil) {
+			delete(s.failures, k)
+		}
+	}
+}
@@ -0,0 +1,48 @@
+package ratelimit
+
+import (
+	"context"
+	"math"
+	"time"
+)
+
+// Limit is a token bucket: Burst requests at once, refilled at Rate per second.
+type Limit struct {
+	Rate  float64
+	Burst int
+}
+
+// Per returns a limit of n requests per period (burst n).
+func Per(n int, period time.Duration) Limit {
+	return Limit{Rate: float64(n) / period.Seconds(), Burst: n}
+}
+
+type Result struct {
+	Allowed    bool
+	Remaining  int
+	RetryAfter time.Duration // Allowed=false болса келесі токенге дейін
+}
+
+// Store keeps buckets, failure counters and locks. MemoryStore is the
+// single-instance implementation; a shared one (Redis, Postgres) is needed
+// when the API runs on several instances.
+type Store interface {
+	// Take removes one token from the bucket at key.
+	Take(ctx context.Context, key string, l Limit) (Result, error)
+	// Fail counts a failure at key; the counter resets after window without failures.
+	Fail(ctx context.Context, key string, window time.Duration) (int, error)
+	// Reset clears the failure counter and lock at key.
+	Reset(ctx context.Context, key string) error
+	Lock(ctx context.Context, key string, d time.Duration) error
+	// LockedFor returns the remaining lock time (0 = not locked).
+	LockedFor(ctx context.Context, key string) (time.Duration, error)
+}
+
+// RetrySeconds rounds up for the Retry-After header (at least 1).
+func RetrySeconds(d time.Duration) int {
+	s := int(math.Ceil(d.Seconds()))
+	if s < 1 {
+		return 1
+	}
+	return s
+}
@@ -0,0 +1,157 @@
+package ratelimit
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+// clock — MemoryStore.now орнына басқарылатын уақыт.
+type clock struct{ t time.Time }
+
+func (c *clock) now() time.Time          { return c.t }
+func (c *clock) advance(d time.Duration) { c.t = c.t.Add(d) }
+func newTestStore() (*MemoryStore, *clock) {
+	c := &clock{t: time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC)}
+	s := NewMemoryStore()
+	s.now = c.now
+	return s, c
+}
+
+func TestTake(t *testing.T) {
+	ctx := context.Background()
+	s, c := newTestStore()
+	limit := Per(2, 4*time.Second) // 0.5 токен/с, burst 2
+
+	steps := []struct {
+		advance   time.Duration
+		allowed   bool
+		remaining int
+		retry     time.Duration
+	}{
+		{0, true, 1, 0},
+		{0, true, 0, 0},
+		{0, false, 0, 2 * time.Second},
+		{time.Second, false, 0, time.Second}, // 0.5 токен
+		{time.Second, true, 0, 0},
+		{time.Minute, true, 1, 0}, // burst-тен асып толмайды
+		{0, true, 0, 0},
+		{0, false, 0, 2 * time.Second},
+	}
+	for i, st := range steps {
+		c.advance(st.advance)
+		res, err := s.Take(ctx, "ip:1", limit)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if res.Allowed != st.allowed || res.Remaining != st.remaining || res.RetryAfter != st.retry {
+			t.Errorf("step %d: got %+v, want allowed=%v remaining=%d retry=%v", i, res, st.allowed, st.remaining, st.retry)
+		}
+	}
+
+	// басқа кілттің өз bucket-і бар
+	if res, _ := s.Take(ctx, "ip:2", limit); !res.Allowed || res.Remaining != 1 {
+		t.Errorf("other key: %+v", res)
+	}
+}
+
+func TestSweep(t *testing.T) {
+	ctx := context.Background()
+	s, c := newTestStore()
+	limit := Per(2, 4*time.Second)
+	s.Take(ctx, "idle", limit)
+	s.Fail(ctx, "fail", time.Minute)
+
+	c.advance(2 * time.Minute)
+	s.Take(ctx, "fresh", limit)
+	if _, ok := s.buckets["idle"]; ok {
+		t.Error("refilled bucket not swept")
+	}
+	if _, ok := s.failures["fail"]; ok {
+		t.Error("expired failure counter not swept")
+	}
+	if _, ok := s.buckets["fresh"]; !ok {
+		t.Error("bucket in use was swept")
+	}
+}
+
+func TestLockoutFailure(t *testing.T) {
+	ctx := context.Background()
+	s, _ := newTestStore()
+	l := DefaultLockout(s)
+
+	want := []time.Duration{
+		0, 0, 0, 0, // 1–4
+		30 * time.Second, time.Minute, 2 * time.Minute, 4 * time.Minute, 8 * time.Minute,
+		16 * time.Minute, 32 * time.Minute, time.Hour, time.Hour, // 12-ден бастап Max
+	}
+	for i, w := range want {
+		n, d, err := l.Failure(ctx, "a@example.com")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if n != i+1 || d != w {
+			t.Errorf("failure %d: got n=%d lock=%v, want lock=%v", i+1, n, d, w)
+		}
+		if locked, _ := l.LockedFor(ctx, "a@example.com"); locked != w {
+			t.Errorf("failure %d: LockedFor = %v, want %v", i+1, locked, w)
+		}
+	}
+
+	if err := l.Success(ctx, "a@example.com"); err != nil {
+		t.Fatal(err)
+	}
+	if locked, _ := l.LockedFor(ctx, "a@example.com"); locked != 0 {
+		t.Errorf("after success LockedFor = %v", locked)
+	}
+	if n, d, _ := l.Failure(ctx, "a@example.com"); n != 1 || d != 0 {
+		t.Errorf("after success: n=%d lock=%v", n, d)
+	}
+}
+
+func TestLockoutWindow(t *testing.T) {
+	ctx := context.Background()
+	s, c := newTestStore()
+	l := DefaultLockout(s)
+
+	tests := []struct {
+		name    string
+		advance time.Duration
+		n       int
+	}{
+		{"first", 0, 1},
+		{"inside window", 10 * time.Minute, 2},
+		{"window passed", 16 * time.Minute, 1},
+	}
+	for _, tt := range tests {
+		c.advance(tt.advance)
+		if n, _, _ := l.Failure(ctx, "k"); n != tt.n {
+			t.Errorf("%s: n = %d, want %d", tt.name, n, tt.n)
+		}
+	}
+
+	// құлыпталған кезде терезе өтсе де санау жалғасады
+	l.Max = 24 * time.Hour
+	l.Base = time.Hour
+	for i := 0; i < 4; i++ {
+		l.Failure(ctx, "k")
+	}
+	c.advance(30 * time.Minute)
+	if n, d, _ := l.Failure(ctx, "k"); n != 6 || d != 2*time.Hour {
+		t.Errorf("while locked: n=%d lock=%v, want 6 and 2h", n, d)
+	}
+}
+
+func TestRetrySeconds(t *testing.T) {
+	tests := []struct {
+		d    time.Duration
+		want int
+	}{
+		{0, 1}, {-time.Second, 1}, {100 * time.Millisecond, 1}, {1500 * time.Millisecond, 2}, {30 * time.Second, 30},
+	}
+	for _, tt := range tests {
+		if got := RetrySeconds(tt.d); got != tt.want {
+			t.Errorf("RetrySeconds(%v) = %d, want %d", tt.d, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,100 @@
+package scoring
+
+import "math"
+
+// Converter converts money between ISO currency codes (currency.Rates).
+type Converter interface {
+  Convert(amount float64, from, to string) (float64, bool)
+}
+
+type AffordabilityStatus string
+
+const (
+  Affordable AffordabilityStatus = "affordable"
+  AffordableWithScholarship AffordabilityStatus = "affordable_with_scholarship"
+  OutOfReach AffordabilityStatus = "out_of_reach"
+  AffordabilityUnknown AffordabilityStatus = "unknown"
+)
+
+// DefaultBudgetCurrency — profiles.budget_currency бос болса.
+const DefaultBudgetCurrency = "USD"
+
+// Cost — бағдарламаның жылдық құны (tuition + қалаушы болса тұру шығыны).
+type Cost struct {
+  TuitionAmount *float64
+  TuitionCurrency string
+  HasScholarship bool
+  ScholarshipPercentMin *int
+  ScholarshipPercentMax *int
+
+  LivingAmount *float64 // nil = есепке алынбайды
+  LivingCurrency string
+}
+
+// Affordability — барлық сомалар студент бюджетінің валютасында.
+type Affordability struct {
+  Status AffordabilityStatus `json:"status"`
+  Currency string `json:"currency"`
+  Budget *float64 `json:"budget,omitempty"`
+  Tuition *float64 `json:"tuition,omitempty"`
+  Living *float64 `json:"living,omitempty"`
+  TotalFull *float64 `json:"total_full,omitempty"` // стипендиясыз
+  TotalBest *float64 `json:"total_best,omitempty"` // ең жоғары стипендиямен
+  ScholarshipNeeded *int `json:"scholarship_needed,omitempty"` // қажетті ең аз стипендия, %
+  Shortfall *float64 `json:"shortfall,omitempty"` // ең жоғары стипендиямен де жетпейтін сома
+}
+
+// Afford compares the student's yearly budget with the program's cost,
+// taking the scholarship range into account.
+func Afford(p Profile, c Cost, conv Converter) Affordability {
+  cur := p.BudgetCurrency
+  if cur == "" { cur = DefaultBudgetCurrency }
+  a := Affordability{Status: AffordabilityUnknown, Currency: cur}
+  if p.BudgetYear == nil || c.TuitionAmount == nil || c.TuitionCurrency == "" || conv == nil {
+    return a
+  }
+  a.Budget = num(*p.BudgetYear)
+
+  tuition, ok := conv.Convert(*c.TuitionAmount, c.TuitionCurrency, cur)
+  if !ok { return a }
+  a.Tuition = num(round2(tuition))
+
+  living := 0.0
+  if c.LivingAmount != nil && c.LivingCurrency != "" {
+    if v, ok := conv.Convert(*c.LivingAmount, c.LivingCurrency, cur); ok {
+      living = v
+      a.Living = num(round2(v))
+    }
+  }
+
+  budget := *p.BudgetYear
+  full := tuition + living
+  a.TotalFull = num(round2(full))
+
+  maxPct := 0
+  if c.HasScholarship && c.ScholarshipPercentMax != nil { maxPct = clampPct(*c.ScholarshipPercentMax) }
+  best := tuition*(1-float64(maxPct)/100) + living
+  a.TotalBest = num(round2(best))
+
+  switch {
+  case budget >= full:
+    a.Status = Affordable
+  case budget >= best && tuition > 0:
+    a.Status = AffordableWithScholarship
+    // 1e-9: (1-0.7)*100 = 30.000000000000004 болып, 31% шықпауы үшін
+    need := int(math.Ceil((1-(budget-living)/tuition)*100 - 1e-9))
+    if c.ScholarshipPercentMin != nil && need < *c.ScholarshipPercentMin { need = clampPct(*c.ScholarshipPercentMin) }
+    need = clampPct(need)
+    a.ScholarshipNeeded = &need
+  default:
+    a.Status = OutOfReach
+    a.Shortfall = num(round2(best - budget))
+  }
+  return a
+}
+
+func clampPct(v int) int {
+  if v < 0 { return 0 }
+  if v > 100 { return 100 }
+  return v
+}
@@ -0,0 +1,110 @@
+package scoring
+
+import "testing"
+
+// perUSD — 1 USD қанша бірлік; тесттерге арналған Converter.
+type perUSD map[string]float64
+
+func (r perUSD) Convert(amount float64, from, to string) (float64, bool) {
+  f, ok1 := r[from]
+  t, ok2 := r[to]
+  if !ok1 || !ok2 { return 0, false }
+  return amount / f * t, true
+}
+
+var testRates = perUSD{"USD": 1, "KZT": 500, "EUR": 0.8}
+
+func TestAfford(t *testing.T) {
+  tests := []struct {
+    name string
+    p Profile
+    c Cost
+    status AffordabilityStatus
+    currency string
+    totalFull, totalBest, shortfall *float64
+    need *int
+  }{
+    {
+      name: "no budget",
+      c: Cost{TuitionAmount: num(10000), TuitionCurrency: "USD"},
+      status: AffordabilityUnknown, currency: "USD",
+    },
+    {
+      name: "no tuition",
+      p: Profile{BudgetYear: num(10000)},
+      status: AffordabilityUnknown, currency: "USD",
+    },
+    {
+      name: "unconvertible currency",
+      p: Profile{BudgetYear: num(10000)},
+      c: Cost{TuitionAmount: num(10000), TuitionCurrency: "XXX"},
+      status: AffordabilityUnknown, currency: "USD",
+    },
+    {
+      name: "budget covers tuition",
+      p: Profile{BudgetYear: num(20000)},
+      c: Cost{TuitionAmount: num(15000), TuitionCurrency: "USD"},
+      status: Affordable, currency: "USD", totalFull: num(15000), totalBest: num(15000),
+    },
+    {
+      name: "needs part of the scholarship range",
+      p: Profile{BudgetYear: num(10000)},
+      c: Cost{TuitionAmount: num(15000), TuitionCurrency: "USD", HasScholarship: true, ScholarshipPercentMin: intp(25), ScholarshipPercentMax: intp(50)},
+      status: AffordableWithScholarship, currency: "USD", totalFull: num(15000), totalBest: num(7500), need: intp(34),
+    },
+    {
+      name: "needed below the smallest scholarship",
+      p: Profile{BudgetYear: num(14000)},
+      c: Cost{TuitionAmount: num(15000), TuitionCurrency: "USD", HasScholarship: true, ScholarshipPercentMin: intp(25), ScholarshipPercentMax: intp(50)},
+      status: AffordableWithScholarship, currency: "USD", totalFull: num(15000), totalBest: num(7500), need: intp(25),
+    },
+    {
+      name: "exact percentage is not rounded up",
+      p: Profile{BudgetYear: num(7000)},
+      c: Cost{TuitionAmount: num(10000), TuitionCurrency: "USD", HasScholarship: true, ScholarshipPercentMax: intp(100)},
+      status: AffordableWithScholarship, currency: "USD", totalFull: num(10000), totalBest: num(0), need: intp(30),
+    },
+    {
+      name: "out of reach even with the best scholarship",
+      p: Profile{BudgetYear: num(5000)},
+      c: Cost{TuitionAmount: num(15000), TuitionCurrency: "USD", HasScholarship: true, ScholarshipPercentMax: intp(50)},
+      status: OutOfReach, currency: "USD", totalFull: num(15000), totalBest: num(7500), shortfall: num(2500),
+    },
+    {
+      name: "scholarship range ignored without has_scholarship",
+      p: Profile{BudgetYear: num(10000)},
+      c: Cost{TuitionAmount: num(15000), TuitionCurrency: "USD", ScholarshipPercentMax: intp(50)},
+      status: OutOfReach, currency: "USD", totalFull: num(15000), totalBest: num(15000), shortfall: num(5000),
+    },
+    {
+      name: "budget in KZT with living costs",
+      p: Profile{BudgetYear: num(6000000), BudgetCurrency: "KZT"},
+      c: Cost{TuitionAmount: num(8000), TuitionCurrency: "EUR", HasScholarship: true, ScholarshipPercentMax: intp(100),
+        LivingAmount: num(5000), LivingCurrency: "USD"},
+      // tuition 5 000 000 KZT + living 2 500 000 KZT
+      status: AffordableWithScholarship, currency: "KZT", totalFull: num(7500000), totalBest: num(2500000), need: intp(30),
+    },
+  }
+  for _, tt := range tests {
+    a := Afford(tt.p, tt.c, testRates)
+    if a.Status != tt.status || a.Currency != tt.currency {
+      t.Errorf("%s: status = %s %s, want %s %s", tt.name, a.Status, a.Currency, tt.status, tt.currency)
+    }
+    if !eqNum(a.TotalFull, tt.totalFull) || !eqNum(a.TotalBest, tt.totalBest) || !eqNum(a.Shortfall, tt.shortfall) {
+      t.Errorf("%s: full=%s best=%s shortfall=%s", tt.name, fmtPtr(a.TotalFull), fmtPtr(a.TotalBest), fmtPtr(a.Shortfall))
+    }
+    if (a.ScholarshipNeeded == nil) != (tt.need == nil) || (tt.need != nil && *a.ScholarshipNeeded != *tt.need) {
+      t.Errorf("%s: scholarship_needed = %s, want %s", tt.name, fmtIntPtr(a.ScholarshipNeeded), fmtIntPtr(tt.need))
+    }
+  }
+}
+
+func TestAffordNilConverter(t *testing.T) {
+  a := Afford(Profile{BudgetYear: num(1)}, Cost{TuitionAmount: num(1), TuitionCurrency: "USD"}, nil)
+  if a.Status != AffordabilityUnknown { t.Errorf("status = %s", a.Status) }
+}
+
+func fmtIntPtr(v *int) string {
+  if v == nil { return "nil" }
+  return fmtNum(float64(*v))
+}
@@ -0,0 +1,106 @@
+package scoring
+
+// Concordance tables — әр тестті ортақ шкалаға келтіру үшін (тілдік тесттер → IELTS,
+// ACT → SAT, GMAT → GRE). Мәндер ресми concordance кестелеріне негізделген, шамамен.
+
+type band struct {
+  min int // осы балл және жоғары
+  ielts float64
+}
+
+// TOEFL iBT → IELTS (ETS)
+var toeflBands = []band{
+  {118, 9.0}, {115, 8.5}, {110, 8.0}, {102, 7.5}, {94, 7.0}, {79, 6.5},
+  {60, 6.0}, {46, 5.5}, {35, 5.0}, {32, 4.5}, {0, 4.0},
+}
+
+// Duolingo English Test (10–160) → IELTS
+var detBands = []band{
+  {155, 8.5}, {150, 8.0}, {140, 7.5}, {130, 7.0}, {120, 6.5}, {110, 6.0},
+  {100, 5.5}, {90, 5.0}, {80, 4.5}, {10, 4.0},
+}
+
+// PTE Academic (10–90) → IELTS (Pearson)
+var pteBands = []band{
+  {86, 9.0}, {83, 8.5}, {79, 8.0}, {73, 7.5}, {65, 7.0}, {58, 6.5},
+  {50, 6.0}, {42, 5.5}, {36, 5.0}, {30, 4.5}, {10, 4.0},
+}
+
+// Cambridge English Scale (C1 Advanced / C2 Proficiency) → IELTS
+var cambridgeBands = []band{
+  {209, 9.0}, {205, 8.5}, {200, 8.0}, {191, 7.5}, {185, 7.0}, {176, 6.5},
+  {169, 6.0}, {162, 5.5}, {154, 5.0}, {80, 4.0},
+}
+
+func toIELTS(bands []band, score int) float64 {
+  for _, b := range bands {
+    if score >= b.min { return b.ielts }
+  }
+  return 0
+}
+
+// fromIELTS — берілген IELTS деңгейіне жететін ең төменгі балл.
+func fromIELTS(bands []band, ielts float64) int {
+  for i := len(bands) - 1; i >= 0; i-- {
+    if bands[i].ielts >= ielts { return bands[i].min }
+  }
+  return bands[0].min
+}
+
+func TOEFLToIELTS(v int) float64 { return toIELTS(toeflBands, v) }
+func DETToIELTS(v int) float64 { return toIELTS(detBands, v) }
+func PTEToIELTS(v int) float64 { return toIELTS(pteBands, v) }
+func CambridgeToIELTS(v int) float64 { return toIELTS(cambridgeBands, v) }
+
+func IELTSToTOEFL(v float64) int { return fromIELTS(toeflBands, v) }
+func IELTSToDET(v float64) int { return fromIELTS(detBands, v) }
+func IELTSToPTE(v float64) int { return fromIELTS(pteBands, v) }
+func IELTSToCambridge(v float64) int { return fromIELTS(cambridgeBands, v) }
+
+// ACT composite → SAT total (College Board / ACT 2018 concordance)
+var actToSAT = map[int]int{
+  36: 1590, 35: 1540, 34: 1500, 33: 1460, 32: 1430, 31: 1400, 30: 1370, 29: 1340,
+  28: 1310, 27: 1280, 26: 1240, 25: 1210, 24: 1180, 23: 1140, 22: 1110, 21: 1080,
+  20: 1040, 19: 1010, 18: 970, 17: 930, 16: 890, 15: 850, 14: 800, 13: 760,
+  12: 710, 11: 670, 10: 630, 9: 590,
+}
+
+func ACTToSAT(act int) int {
+  if act >= 36 { return 1590 }
+  if act < 9 { return 400 + act*20 }
+  return actToSAT[act]
+}
+
+// SATToACT — SAT балына сәйкес келетін ең жоғары ACT.
+func SATToACT(sat int) int {
+  for act := 36; act >= 9; act-- {
+    if sat >= actToSAT[act] { return act }
+  }
+  return 1
+}
+
+// GMAT Classic total (200–800) ↔ GRE total (260–340), piecewise linear approximation.
+// Profile, admin and DB checks accept the same 200–800 range.
+var gmatGRE = [][2]float64{
+  {200, 260}, {320, 280}, {400, 290}, {480, 300}, {560, 310}, {640, 320}, {720, 330}, {800, 340},
+}
+
+func GMATToGRE(gmat int) int {
+  return int(piecewise(float64(gmat), gmatGRE, 0, 1) + 0.5)
+}
+
+func GREToGMAT(gre int) int {
+  return int(piecewise(float64(gre), gmatGRE, 1, 0) + 0.5)
+}
+
+func piecewise(x float64, table [][2]float64, in, out int) float64 {
+  if x <= table[0][in] { return table[0][out] }
+  for i := 1; i < len(table); i++ {
+    if x <= table[i][in] {
+      x0, x1 := table[i-1][in], table[i][in]
+      y0, y1 := table[i-1][out], table[i][out]
+      return y0 + (x-x0)*(y1-y0)/(x1-x0)
+    }
+  }
+  return table[len(table)-1][out]
+}
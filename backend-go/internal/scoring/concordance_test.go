@@ -0,0 +1,123 @@
+package scoring
+
+import "testing"
+
+func TestToIELTS(t *testing.T) {
+  tests := []struct {
+    name string
+    conv func(int) float64
+    score int
+    want float64
+  }{
+    {"toefl", TOEFLToIELTS, 120, 9.0},
+    {"toefl", TOEFLToIELTS, 110, 8.0},
+    {"toefl", TOEFLToIELTS, 100, 7.0},
+    {"toefl", TOEFLToIELTS, 79, 6.5},
+    {"toefl", TOEFLToIELTS, 78, 6.0},
+    {"toefl", TOEFLToIELTS, 0, 4.0},
+    {"det", DETToIELTS, 160, 8.5},
+    {"det", DETToIELTS, 120, 6.5},
+    {"det", DETToIELTS, 119, 6.0},
+    {"pte", PTEToIELTS, 65, 7.0},
+    {"pte", PTEToIELTS, 90, 9.0},
+    {"cambridge", CambridgeToIELTS, 185, 7.0},
+    {"cambridge", CambridgeToIELTS, 80, 4.0},
+  }
+  for _, tt := range tests {
+    if got := tt.conv(tt.score); got != tt.want {
+      t.Errorf("%s %d → IELTS %g, want %g", tt.name, tt.score, got, tt.want)
+    }
+  }
+}
+
+func TestFromIELTS(t *testing.T) {
+  tests := []struct {
+    name string
+    conv func(float64) int
+    ielts float64
+    want int
+  }{
+    {"toefl", IELTSToTOEFL, 7.0, 94},
+    {"toefl", IELTSToTOEFL, 6.5, 79},
+    {"toefl", IELTSToTOEFL, 9.5, 118}, // кестеден жоғары: ең үлкен шек
+    {"det", IELTSToDET, 6.5, 120},
+    {"pte", IELTSToPTE, 7.5, 73},
+    {"cambridge", IELTSToCambridge, 6.5, 176},
+  }
+  for _, tt := range tests {
+    if got := tt.conv(tt.ielts); got != tt.want {
+      t.Errorf("IELTS %g → %s %d, want %d", tt.ielts, tt.name, got, tt.want)
+    }
+  }
+}
+
+// fromIELTS must return the lowest score that still reaches the band.
+func TestIELTSBandsConsistent(t *testing.T) {
+  tables := map[string][]band{"toefl": toeflBands, "det": detBands, "pte": pteBands, "cambridge": cambridgeBands}
+  for name, bands := range tables {
+    for _, b := range bands {
+      min := fromIELTS(bands, b.ielts)
+      if got := toIELTS(bands, min); got < b.ielts {
+        t.Errorf("%s: fromIELTS(%g) = %d scores only %g", name, b.ielts, min, got)
+      }
+      if min > bands[len(bands)-1].min && toIELTS(bands, min-1) >= b.ielts {
+        t.Errorf("%s: fromIELTS(%g) = %d is not the lowest score", name, b.ielts, min)
+      }
+    }
+  }
+}
+
+func TestACTSAT(t *testing.T) {
+  tests := []struct {
+    act, sat int
+  }{
+    {36, 1590}, {40, 1590}, {31, 1400}, {24, 1180}, {9, 590}, {5, 500},
+  }
+  for _, tt := range tests {
+    if got := ACTToSAT(tt.act); got != tt.sat {
+      t.Errorf("ACTToSAT(%d) = %d, want %d", tt.act, got, tt.sat)
+    }
+  }
+
+  back := []struct {
+    sat, act int
+  }{
+    {1600, 36}, {1400, 31}, {1200, 24}, {1180, 24}, {500, 1},
+  }
+  for _, tt := range back {
+    if got := SATToACT(tt.sat); got != tt.act {
+      t.Errorf("SATToACT(%d) = %d, want %d", tt.sat, got, tt.act)
+    }
+  }
+
+  for act := 9; act <= 36; act++ {
+    if got := SATToACT(ACTToSAT(act)); got != act {
+      t.Errorf("SATToACT(ACTToSAT(%d)) = %d", act, got)
+    }
+  }
+}
+
+func TestGMATGRE(t *testing.T) {
+  tests := []struct {
+    gmat, gre int
+  }{
+    {200, 260}, {400, 290}, {600, 315}, {800, 340},
+    {100, 260}, {805, 340}, // шкаладан тыс: шеткі мәндер
+  }
+  for _, tt := range tests {
+    if got := GMATToGRE(tt.gmat); got != tt.gre {
+      t.Errorf("GMATToGRE(%d) = %d, want %d", tt.gmat, got, tt.gre)
+    }
+  }
+
+  back := []struct {
+    gre, gmat int
+  }{
+    {260, 200}, {315, 600}, {340, 800}, {250, 200},
+  }
+  for _, tt := range back {
+    if got := GREToGMAT(tt.gre); got != tt.gmat {
+      t.Errorf("GREToGMAT(%d) = %d, want %d", tt.gre, got, tt.gmat)
+    }
+  }
+}
@@ -0,0 +1,122 @@
+package scoring
+
+import (
+  "encoding/json"
+  "errors"
+  "fmt"
+  "os"
+  "strings"
+)
+
+// Policy — Compute-тың салмақтары, шектеулері, айыппұлдары мен бонустары.
+// Атауы + нұсқасы scores кестесіне жазылады, сондықтан нәтижені қайта шығаруға болады.
+type Policy struct {
+  Name string `json:"name"`
+  Version int `json:"version"`
+
+  Weights Weights `json:"weights"`
+  Caps Caps `json:"caps"`
+  Penalties Penalties `json:"penalties"`
+  Bonuses Bonuses `json:"bonuses"`
+}
+
+// Weights — әр блоктың максимал ұпайы; қосындысы әдетте 100.
+type Weights struct {
+  GPA float64 `json:"gpa"`
+  Language float64 `json:"language"`
+  Tests float64 `json:"tests"`
+  Extra float64 `json:"extra"`
+}
+
+type Caps struct {
+  Max int `json:"max"` // жалпы жоғарғы шек
+  FailedRequirement int `json:"failed_requirement"` // кез келген min талап орындалмаса (0 = шек жоқ)
+}
+
+// Penalties — талап орындалмаса не дерек жоқ болса алынатын ұпай.
+type Penalties struct {
+  BelowMinGPA int `json:"below_min_gpa"`
+  BelowMinLanguage int `json:"below_min_language"`
+  BelowMinTest int `json:"below_min_test"`
+  MissingLanguage int `json:"missing_language"`
+  MissingRequiredTest int `json:"missing_required_test"`
+}
+
+// Bonuses — Extra блогы (Weights.Extra-мен шектеледі).
+type Bonuses struct {
+  PerAward float64 `json:"per_award"`
+  MaxAwards int `json:"max_awards"`
+  Achievements float64 `json:"achievements"` // achievements_summary толтырылса
+  AchievementsMinLen int `json:"achievements_min_len"`
+}
+
+// DefaultPolicy keeps the original 40/30/20/10 split.
+func DefaultPolicy() Policy {
+  return Policy{
+    Name: "default",
+    Version: 1,
+    Weights: Weights{GPA: 40, Language: 30, Tests: 20, Extra: 10},
+    Caps: Caps{Max: 100},
+    Bonuses: Bonuses{PerAward: 2, MaxAwards: 3, Achievements: 4, AchievementsMinLen: 20},
+  }
+}
+
+func (pol Policy) ID() string {
+  return fmt.Sprintf("%s@%d", pol.Name, pol.Version)
+}
+
+func (pol Policy) Validate() error {
+  if strings.TrimSpace(pol.Name) == "" { return errors.New("policy name required") }
+  if pol.Version <= 0 { return errors.New("policy version must be positive") }
+  w := pol.Weights
+  if w.GPA < 0 || w.Language < 0 || w.Tests < 0 || w.Extra < 0 {
+    return errors.New("policy weights must be non-negative")
+  }
+  if pol.Caps.Max <= 0 || pol.Caps.Max > 100 {
+    return errors.New("policy caps.max must be in 1..100")
+  }
+  if pol.Caps.FailedRequirement < 0 || pol.Caps.FailedRequirement > pol.Caps.Max {
+    return errors.New("policy caps.failed_requirement must be in 0..caps.max")
+  }
+  return nil
+}
+
+// ParsePolicy decodes a JSON policy; missing sections fall back to DefaultPolicy.
+func ParsePolicy(data []byte) (Policy, error) {
+  pol := DefaultPolicy()
+  if err := json.Unmarshal(data, &pol); err != nil { return Policy{}, err }
+  if err := pol.Validate(); err != nil { return Policy{}, err }
+  return pol, nil
+}
+
+func LoadPolicyFile(path string) (Policy, error) {
+  data, err := os.ReadFile(path)
+  if err != nil { return Policy{}, err }
+  return ParsePolicy(data)
+}
+
+func (pol Policy) extra(p Profile) float64 {
+  b := pol.Bonuses
+  bonus := 0.0
+  if n := countAwards(p.Awards); n > 0 {
+    if b.MaxAwards > 0 && n > b.MaxAwards { n = b.MaxAwards }
+    bonus += b.PerAward * float64(n)
+  }
+  if p.AchievementsSummary != nil {
+    if s := strings.TrimSpace(*p.AchievementsSummary); s != "" && len([]rune(s)) >= b.AchievementsMinLen {
+      bonus += b.Achievements
+    }
+  }
+  if bonus > pol.Weights.Extra { bonus = pol.Weights.Extra }
+  return bonus
+}
+
+// countAwards — awards өрісі үтір/нүктелі үтір/жаңа жол арқылы бөлінген тізім.
+func countAwards(s *string) int {
+  if s == nil { return 0 }
+  n := 0
+  for _, part := range strings.FieldsFunc(*s, func(r rune) bool { return r == ',' || r == ';' || r == '\n' }) {
+    if strings.TrimSpace(part) != "" { n++ }
+  }
+  return n
+}
@@ -0,0 +1,156 @@
+package scoring
+
+import (
+  "strings"
+  "testing"
+)
+
+func intp(v int) *int { return &v }
+func strp(v string) *string { return &v }
+
+func TestPolicyValidate(t *testing.T) {
+  tests := []struct {
+    name string
+    edit func(*Policy)
+    wantErr string
+  }{
+    {"default", func(*Policy) {}, ""},
+    {"empty name", func(p *Policy) { p.Name = " " }, "name required"},
+    {"zero version", func(p *Policy) { p.Version = 0 }, "version must be positive"},
+    {"negative weight", func(p *Policy) { p.Weights.Tests = -1 }, "weights must be non-negative"},
+    {"zero max", func(p *Policy) { p.Caps.Max = 0 }, "caps.max"},
+    {"max over 100", func(p *Policy) { p.Caps.Max = 101 }, "caps.max"},
+    {"negative failed cap", func(p *Policy) { p.Caps.FailedRequirement = -1 }, "failed_requirement"},
+    {"failed cap over max", func(p *Policy) { p.Caps.Max = 80; p.Caps.FailedRequirement = 90 }, "failed_requirement"},
+  }
+  for _, tt := range tests {
+    pol := DefaultPolicy()
+    tt.edit(&pol)
+    err := pol.Validate()
+    if tt.wantErr == "" {
+      if err != nil { t.Errorf("%s: unexpected error %v", tt.name, err) }
+      continue
+    }
+    if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+      t.Errorf("%s: error = %v, want %q", tt.name, err, tt.wantErr)
+    }
+  }
+}
+
+func TestParsePolicy(t *testing.T) {
+  pol, err := ParsePolicy([]byte(`{"name":"strict","version":2,"weights":{"gpa":50},"caps":{"failed_requirement":40}}`))
+  if err != nil { t.Fatal(err) }
+  if pol.ID() != "strict@2" { t.Errorf("ID = %q", pol.ID()) }
+  // берілмеген өрістер DefaultPolicy-ден қалады
+  if pol.Weights.GPA != 50 || pol.Weights.Language != 30 || pol.Caps.Max != 100 || pol.Caps.FailedRequirement != 40 {
+    t.Errorf("merged policy = %+v", pol)
+  }
+
+  for _, bad := range []string{`{`, `{"version":0}`, `{"caps":{"max":0}}`} {
+    if _, err := ParsePolicy([]byte(bad)); err == nil { t.Errorf("ParsePolicy(%s): expected error", bad) }
+  }
+}
+
+func TestCompute(t *testing.T) {
+  strict := DefaultPolicy()
+  strict.Caps.FailedRequirement = 20
+  strict.Penalties.MissingLanguage = 5
+
+  tests := []struct {
+    name string
+    pol Policy
+    p Profile
+    r Requirements
+    want int
+    reasons []string
+  }{
+    {
+      name: "perfect profile",
+      pol: DefaultPolicy(),
+      p: Profile{GPA: num(4), GradingSystem: "us_4", IELTS: num(9), SAT: intp(1600),
+        Awards: strp("a, b, c"), AchievementsSummary: strp(strings.Repeat("x", 30))},
+      want: 100,
+    },
+    {
+      name: "no tests",
+      pol: DefaultPolicy(),
+      p: Profile{GPA: num(3), GradingSystem: "us_4", IELTS: num(6)},
+      want: 50, // 30 + 20
+      reasons: []string{ReasonTestMissing},
+    },
+    {
+      name: "empty profile",
+      pol: DefaultPolicy(),
+      p: Profile{},
+      want: 0,
+      reasons: []string{ReasonGPAMissing, ReasonLanguageMissing, ReasonTestMissing},
+    },
+    {
+      name: "legacy gpa_scale",
+      pol: DefaultPolicy(),
+      p: Profile{GPA: num(90), GPAScale: num(100), IELTS: num(9), SAT: intp(1600)},
+      want: 87, // round(40*3.7/4)=37 + 30 + 20
+    },
+    {
+      name: "inverted scale meets minimum",
+      pol: DefaultPolicy(),
+      p: Profile{GPA: num(1.0), GradingSystem: "de_5", IELTS: num(9), SAT: intp(1600)},
+      r: Requirements{MinGPA: num(3.5)},
+      want: 90,
+    },
+    {
+      name: "inverted scale below minimum",
+      pol: DefaultPolicy(),
+      p: Profile{GPA: num(3.0), GradingSystem: "us_4", IELTS: num(9), SAT: intp(1600)},
+      r: Requirements{MinGPA: num(2.0), GPASystem: "de_5"}, // 2.0 de_5 = 3.3
+      want: 80,
+      reasons: []string{ReasonGPABelowMin},
+    },
+    {
+      name: "toefl and act concordance",
+      pol: DefaultPolicy(),
+      p: Profile{GPA: num(4), GradingSystem: "us_4", TOEFL: intp(100), ACT: intp(31)},
+      r: Requirements{MinIELTS: num(6.5), MinSAT: intp(1400)},
+      want: 81, // 40 + round(30*7/9)=23 + round(20*1400/1600)=18
+    },
+    {
+      name: "required test missing",
+      pol: DefaultPolicy(),
+      p: Profile{GPA: num(4), GradingSystem: "us_4", IELTS: num(9)},
+      r: Requirements{MinGRE: intp(310)},
+      want: 70,
+      reasons: []string{ReasonTestRequired},
+    },
+    {
+      name: "failed requirement cap",
+      pol: strict,
+      p: Profile{GPA: num(4), GradingSystem: "us_4", IELTS: num(6), SAT: intp(1600)},
+      r: Requirements{MinIELTS: num(7)},
+      want: 20,
+      reasons: []string{ReasonLanguageBelowMin},
+    },
+    {
+      name: "missing language penalty",
+      pol: strict,
+      p: Profile{GPA: num(4), GradingSystem: "us_4", SAT: intp(1600)},
+      want: 55, // 40 + 20 - 5
+      reasons: []string{ReasonLanguageMissing},
+    },
+  }
+  for _, tt := range tests {
+    res := tt.pol.Compute(tt.p, tt.r)
+    if res.Score != tt.want { t.Errorf("%s: score = %d, want %d", tt.name, res.Score, tt.want) }
+    if got := reasonCodes(res.Reasons); strings.Join(got, ",") != strings.Join(tt.reasons, ",") {
+      t.Errorf("%s: reasons = %v, want %v", tt.name, got, tt.reasons)
+    }
+    if res.PolicyName != tt.pol.Name || res.PolicyVersion != tt.pol.Version {
+      t.Errorf("%s: policy = %s@%d", tt.name, res.PolicyName, res.PolicyVersion)
+    }
+  }
+}
+
+func reasonCodes(rs []Reason) []string {
+  out := []string{}
+  for _, r := range rs { out = append(out, r.Code) }
+  return out
+}
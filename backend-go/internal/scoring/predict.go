@@ -0,0 +1,103 @@
+package scoring
+
+import "math"
+
+// Stats — бағдарламаның соңғы жылдардағы қабылдау статистикасы (орташа мәндер).
+// AcceptanceRate пайызбен (0..100), AvgGPA 4.0 шкаласында.
+type Stats struct {
+  AcceptanceRate *float64
+  AvgGPA *float64
+  AvgIELTS *float64
+  AvgSAT *int
+}
+
+func (s *Stats) empty() bool {
+  return s == nil || (s.AcceptanceRate == nil && s.AvgGPA == nil && s.AvgIELTS == nil && s.AvgSAT == nil)
+}
+
+type Category string
+
+const (
+  CategoryReach Category = "reach"
+  CategoryTarget Category = "target"
+  CategorySafety Category = "safety"
+)
+
+const (
+  ModelLogistic = "logistic"
+  ModelRule = "rule"
+)
+
+type Prediction struct {
+  Result
+  Probability int
+  Category Category
+  Model string
+}
+
+// Predict returns an admission probability for the program. With historical
+// stats it starts from the acceptance-rate prior and shifts the logit by how
+// far the student is from the typical admitted student; without stats it
+// falls back to the rule-based Compute score.
+func Predict(p Profile, r Requirements, s *Stats) Prediction {
+  return DefaultPolicy().Predict(p, r, s)
+}
+
+func (pol Policy) Predict(p Profile, r Requirements, s *Stats) Prediction {
+  base := pol.Compute(p, r)
+  if s.empty() {
+    return Prediction{Result: base, Probability: base.Score, Category: categorize(base.Score), Model: ModelRule}
+  }
+
+  logit := 0.0
+  if s.AcceptanceRate != nil {
+    rate := math.Max(1, math.Min(99, *s.AcceptanceRate)) / 100
+    logit = math.Log(rate / (1 - rate))
+  }
+
+  // GPA: 1.0 point over/under the admitted average ≈ 2.5 logit
+  if base.GPA4 != nil {
+    if s.AvgGPA != nil {
+      logit += 2.5 * (*base.GPA4 - *s.AvgGPA)
+    }
+    if r.MinGPA != nil && *base.GPA4+1e-9 < requirementSystem(r).ToGPA4(*r.MinGPA) {
+      logit -= 1.5
+    }
+  } else {
+    logit -= 0.5
+  }
+
+  // Language: minimum is a hard requirement in most programs
+  if lang := language(p, r); lang.has {
+    if s.AvgIELTS != nil {
+      logit += 1.2 * (lang.ielts - *s.AvgIELTS)
+    }
+    if !lang.met {
+      logit -= 2.0
+    }
+  } else {
+    logit -= 1.0
+  }
+
+  // Standardized tests: 100 SAT points ≈ 1.0 logit
+  if base.SATEquivalent != nil && s.AvgSAT != nil {
+    logit += float64(*base.SATEquivalent-*s.AvgSAT) / 100
+  }
+  for _, f := range academicTests(p, r) {
+    if !f.met {
+      logit -= 1.0
+    }
+  }
+
+  prob := int(math.Round(100 / (1 + math.Exp(-logit))))
+  if prob < 1 { prob = 1 }
+  if prob > 99 { prob = 99 }
+
+  return Prediction{Result: base, Probability: prob, Category: categorize(prob), Model: ModelLogistic}
+}
+
+func categorize(prob int) Category {
+  if prob < 30 { return CategoryReach }
+  if prob < 80 { return CategoryTarget }
+  return CategorySafety
+}
@@ -0,0 +1,94 @@
+package scoring
+
+import "testing"
+
+func TestPredict(t *testing.T) {
+  avg := Profile{GPA: num(3.5), GradingSystem: "us_4", IELTS: num(7)}
+
+  tests := []struct {
+    name string
+    p Profile
+    r Requirements
+    s *Stats
+    prob int
+    cat Category
+    model string
+  }{
+    {
+      name: "no stats falls back to rule score",
+      p: Profile{GPA: num(3), GradingSystem: "us_4", IELTS: num(6)},
+      prob: 50, cat: CategoryTarget, model: ModelRule,
+    },
+    {
+      name: "empty stats falls back to rule score",
+      p: Profile{GPA: num(3), GradingSystem: "us_4", IELTS: num(6)},
+      s: &Stats{},
+      prob: 50, cat: CategoryTarget, model: ModelRule,
+    },
+    {
+      name: "typical admitted student",
+      p: avg,
+      s: &Stats{AcceptanceRate: num(50), AvgGPA: num(3.5), AvgIELTS: num(7)},
+      prob: 50, cat: CategoryTarget, model: ModelLogistic,
+    },
+    {
+      name: "gpa one point above average",
+      p: Profile{GPA: num(4), GradingSystem: "us_4", IELTS: num(7)},
+      s: &Stats{AcceptanceRate: num(50), AvgGPA: num(3)},
+      prob: 92, cat: CategorySafety, model: ModelLogistic, // logit +2.5
+    },
+    {
+      name: "selective program, gpa below minimum",
+      p: Profile{GPA: num(3), GradingSystem: "us_4", IELTS: num(7)},
+      r: Requirements{MinGPA: num(3.5)},
+      s: &Stats{AcceptanceRate: num(10), AvgGPA: num(3.5)},
+      prob: 1, cat: CategoryReach, model: ModelLogistic, // ln(1/9) - 1.25 - 1.5, clamped to 1
+    },
+    {
+      name: "sat above average",
+      p: Profile{GPA: num(3.5), GradingSystem: "us_4", IELTS: num(7), SAT: intp(1500)},
+      s: &Stats{AcceptanceRate: num(50), AvgSAT: intp(1400)},
+      prob: 73, cat: CategoryTarget, model: ModelLogistic, // logit +1
+    },
+    {
+      name: "missing gpa and language",
+      p: Profile{},
+      s: &Stats{AcceptanceRate: num(50)},
+      prob: 18, cat: CategoryReach, model: ModelLogistic, // logit -0.5 - 1.0
+    },
+    {
+      name: "required test missing",
+      p: avg,
+      r: Requirements{MinSAT: intp(1400)},
+      s: &Stats{AcceptanceRate: num(50)},
+      prob: 27, cat: CategoryReach, model: ModelLogistic, // logit -1
+    },
+    {
+      name: "acceptance rate clamped to 99",
+      p: avg,
+      s: &Stats{AcceptanceRate: num(100)},
+      prob: 99, cat: CategorySafety, model: ModelLogistic,
+    },
+  }
+  for _, tt := range tests {
+    got := Predict(tt.p, tt.r, tt.s)
+    if got.Probability != tt.prob || got.Category != tt.cat || got.Model != tt.model {
+      t.Errorf("%s: got %d%% %s (%s), want %d%% %s (%s)", tt.name, got.Probability, got.Category, got.Model, tt.prob, tt.cat, tt.model)
+    }
+    if got.Score != Compute(tt.p, tt.r).Score {
+      t.Errorf("%s: embedded score %d differs from Compute", tt.name, got.Score)
+    }
+  }
+}
+
+func TestCategorize(t *testing.T) {
+  tests := []struct {
+    prob int
+    want Category
+  }{
+    {1, CategoryReach}, {29, CategoryReach}, {30, CategoryTarget}, {79, CategoryTarget}, {80, CategorySafety}, {99, CategorySafety},
+  }
+  for _, tt := range tests {
+    if got := categorize(tt.prob); got != tt.want { t.Errorf("categorize(%d) = %s, want %s", tt.prob, got, tt.want) }
+  }
+}
@@ -0,0 +1,56 @@
+package scoring
+
+import "strconv"
+
+type Severity string
+
+const (
+  SeverityInfo Severity = "info"
+  SeverityWarning Severity = "warning"
+  SeverityBlocker Severity = "blocker" // min талап орындалмаған
+)
+
+const (
+  FactorGPA = "gpa"
+  FactorLanguage = "language"
+  FactorTests = "tests"
+  FactorExtra = "extra"
+)
+
+// Reason codes — i18n каталогындағы "reason.<code>" кілттері.
+const (
+  ReasonGPAMissing = "gpa_missing"
+  ReasonGPABelowMin = "gpa_below_min"
+  ReasonLanguageMissing = "language_missing"
+  ReasonLanguageBelowMin = "language_below_min"
+  ReasonTestMissing = "test_missing"
+  ReasonTestRequired = "test_required"
+  ReasonTestBelowMin = "test_below_min"
+)
+
+// Reason — құрылымдық себеп. Value және Required бір шкалада
+// (GPA — бағдарлама шкаласында, тест — студент тапсырған тест шкаласында).
+// Message тек жауап беру алдында каталогтан толтырылады.
+type Reason struct {
+  Code string `json:"code"`
+  Severity Severity `json:"severity"`
+  Factor string `json:"factor"`
+  Test string `json:"test,omitempty"`
+  Value *float64 `json:"value,omitempty"`
+  Required *float64 `json:"required,omitempty"`
+  Message string `json:"message,omitempty"`
+}
+
+// Args returns the placeholders the message catalog substitutes.
+func (r Reason) Args() map[string]string {
+  args := map[string]string{"test": r.Test}
+  if r.Value != nil { args["value"] = fmtNum(*r.Value) }
+  if r.Required != nil { args["required"] = fmtNum(*r.Required) }
+  return args
+}
+
+func fmtNum(v float64) string {
+  return strconv.FormatFloat(v, 'f', -1, 64)
+}
+
+func num(v float64) *float64 { return &v }
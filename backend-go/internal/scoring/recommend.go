@@ -0,0 +1,206 @@
+package scoring
+
+import (
+  "math"
+  "sort"
+  "strings"
+)
+
+// Recommendation codes — i18n каталогындағы "recommendation.<code>" кілттері.
+const (
+  RecRaiseGPA = "raise_gpa"
+  RecAddGPA = "add_gpa"
+  RecRaiseLanguage = "raise_language"
+  RecRaiseLanguageBand = "raise_language_band"
+  RecAddLanguage = "add_language"
+  RecRaiseTest = "raise_test"
+  RecAddTest = "add_test"
+  RecAddAwards = "add_awards"
+  RecAddAchievements = "add_achievements"
+)
+
+// Recommendation — нақты әрекет және оның болжамды әсері.
+// Impact — Compute ұпайының өсімі; ProbabilityImpact статистика болса ғана.
+type Recommendation struct {
+  Code string `json:"code"`
+  Factor string `json:"factor"`
+  Test string `json:"test,omitempty"`
+  Current *float64 `json:"current,omitempty"`
+  Target *float64 `json:"target,omitempty"`
+  Blocking bool `json:"blocking"` // min талапты жабады
+  Impact int `json:"impact"`
+  ProbabilityImpact *int `json:"probability_impact,omitempty"`
+  Message string `json:"message,omitempty"`
+}
+
+func (rec Recommendation) Args() map[string]string {
+  args := map[string]string{"test": rec.Test, "impact": fmtNum(float64(rec.Impact))}
+  if rec.Current != nil { args["current"] = fmtNum(*rec.Current) }
+  if rec.Target != nil { args["target"] = fmtNum(*rec.Target) }
+  return args
+}
+
+// Recommend simulates each improvement on a copy of the profile and keeps
+// the ones that raise the score or close a failed minimum, best first.
+func (pol Policy) Recommend(p Profile, r Requirements, s *Stats) []Recommendation {
+  base := pol.Compute(p, r)
+  var basePred *Prediction
+  if !s.empty() {
+    bp := pol.Predict(p, r, s)
+    basePred = &bp
+  }
+
+  out := []Recommendation{}
+  try := func(rec Recommendation, changed Profile) {
+    rec.Impact = pol.Compute(changed, r).Score - base.Score
+    if basePred != nil {
+      d := pol.Predict(changed, r, s).Probability - basePred.Probability
+      rec.ProbabilityImpact = &d
+    }
+    if rec.Impact > 0 || rec.Blocking || (rec.ProbabilityImpact != nil && *rec.ProbabilityImpact > 0) {
+      out = append(out, rec)
+    }
+  }
+
+  // GPA
+  if g, sys, ok := studentGPA(p); ok {
+    if r.MinGPA != nil {
+      reqSys := requirementSystem(r)
+      if sys.ToGPA4(g)+1e-9 < reqSys.ToGPA4(*r.MinGPA) {
+        target := round2(sys.FromGPA4(reqSys.ToGPA4(*r.MinGPA)))
+        changed := p
+        changed.GPA = &target
+        try(Recommendation{Code: RecRaiseGPA, Factor: FactorGPA, Current: num(g), Target: num(target), Blocking: true}, changed)
+      }
+    }
+  } else {
+    target := 3.0
+    if r.MinGPA != nil { target = requirementSystem(r).ToGPA4(*r.MinGPA) }
+    target = round2(target)
+    changed := p
+    changed.GPA, changed.GradingSystem = &target, "us_4"
+    try(Recommendation{Code: RecAddGPA, Factor: FactorGPA, Target: num(target), Blocking: r.MinGPA != nil}, changed)
+  }
+
+  // Language
+  lang := language(p, r)
+  switch {
+  case lang.has && !lang.met:
+    if target := lang.requiredIn(r, lang.test); target != nil {
+      try(Recommendation{
+        Code: RecRaiseLanguage, Factor: FactorLanguage, Test: lang.test,
+        Current: num(lang.value), Target: target, Blocking: true,
+      }, withTest(p, lang.test, *target))
+    }
+  case lang.has && lang.test == "IELTS" && lang.value < 8:
+    target := math.Floor(lang.value*2)/2 + 0.5
+    try(Recommendation{
+      Code: RecRaiseLanguageBand, Factor: FactorLanguage, Test: "IELTS",
+      Current: num(lang.value), Target: num(target),
+    }, withTest(p, "IELTS", target))
+  case !lang.has:
+    target := 6.5
+    if lang.required != nil { target = *lang.required }
+    try(Recommendation{
+      Code: RecAddLanguage, Factor: FactorLanguage, Test: "IELTS",
+      Target: num(target), Blocking: lang.required != nil,
+    }, withTest(p, "IELTS", target))
+  }
+
+  // Standardized tests
+  fams := academicTests(p, r)
+  _, chosen, anyRequired := testPart(fams, pol.Weights.Tests)
+  for _, f := range fams {
+    if f.required == nil { continue }
+    if !f.has {
+      test := mainTest(f.label)
+      try(Recommendation{
+        Code: RecAddTest, Factor: FactorTests, Test: test,
+        Target: num(float64(*f.required)), Blocking: true,
+      }, withTest(p, test, float64(*f.required)))
+    } else if !f.met {
+      target := f.requiredIn(r)
+      try(Recommendation{
+        Code: RecRaiseTest, Factor: FactorTests, Test: f.test,
+        Current: num(float64(f.native)), Target: num(float64(target)), Blocking: true,
+      }, withTest(p, f.test, float64(target)))
+    }
+  }
+  if chosen == nil && !anyRequired {
+    // міндетті емес тест: деңгейге сай тестті ұсынамыз, белгісіз болса ештеңе
+    test, target := optionalTest(r.DegreeLevel, s)
+    if test != "" {
+      try(Recommendation{Code: RecAddTest, Factor: FactorTests, Test: test, Target: num(float64(target))}, withTest(p, test, float64(target)))
+    }
+  }
+
+  // Extra
+  if countAwards(p.Awards) == 0 && pol.Bonuses.PerAward > 0 {
+    changed := p
+    awards := "award"
+    changed.Awards = &awards
+    try(Recommendation{Code: RecAddAwards, Factor: FactorExtra}, changed)
+  }
+  if p.AchievementsSummary == nil || len([]rune(*p.AchievementsSummary)) < pol.Bonuses.AchievementsMinLen {
+    changed := p
+    summary := strings.Repeat("x", pol.Bonuses.AchievementsMinLen+1)
+    changed.AchievementsSummary = &summary
+    try(Recommendation{Code: RecAddAchievements, Factor: FactorExtra}, changed)
+  }
+
+  sort.SliceStable(out, func(i, j int) bool {
+    if out[i].Impact != out[j].Impact { return out[i].Impact > out[j].Impact }
+    return out[i].Blocking && !out[j].Blocking
+  })
+  return out
+}
+
+func mainTest(label string) string {
+  switch label {
+  case "SAT/ACT":
+    return "SAT"
+  case "GRE/GMAT":
+    return "GRE"
+  }
+  return label
+}
+
+// withTest returns a copy of p with one test score replaced.
+func withTest(p Profile, test string, v float64) Profile {
+  i := int(math.Ceil(v))
+  switch test {
+  case "IELTS":
+    p.IELTS = &v
+  case "TOEFL":
+    p.TOEFL = &i
+  case "DET":
+    p.DET = &i
+  case "PTE":
+    p.PTE = &i
+  case "Cambridge":
+    p.Cambridge = &i
+  case "SAT":
+    p.SAT = &i
+  case "ACT":
+    p.ACT = &i
+  case "GRE":
+    p.GRE = &i
+  case "GMAT":
+    p.GMAT = &i
+  case "ЕНТ":
+    p.UNT = &i
+  }
+  return p
+}
+
+// optionalTest — no test is required; the one worth taking for the degree level.
+func optionalTest(level string, s *Stats) (string, int) {
+  switch level {
+  case "bachelor":
+    if s != nil && s.AvgSAT != nil { return "SAT", *s.AvgSAT }
+    return "SAT", 1400
+  case "master":
+    return "GRE", 315
+  }
+  return "", 0
+}
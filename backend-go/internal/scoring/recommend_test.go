@@ -0,0 +1,126 @@
+package scoring
+
+import (
+  "strings"
+  "testing"
+)
+
+func findRec(recs []Recommendation, code, test string) *Recommendation {
+  for i := range recs {
+    if recs[i].Code == code && (test == "" || recs[i].Test == test) { return &recs[i] }
+  }
+  return nil
+}
+
+func TestRecommend(t *testing.T) {
+  strong := Profile{GPA: num(4), GradingSystem: "us_4", IELTS: num(9)}
+
+  tests := []struct {
+    name string
+    p Profile
+    r Requirements
+    s *Stats
+    code, test string // күтілетін ұсыныс; code бос болса — add_test болмауы керек
+    current, target *float64
+    blocking bool
+  }{
+    {
+      name: "master's program suggests GRE",
+      p: strong, r: Requirements{DegreeLevel: "master"},
+      code: RecAddTest, test: "GRE", target: num(315),
+    },
+    {
+      name: "bachelor's program suggests SAT at the admitted average",
+      p: strong, r: Requirements{DegreeLevel: "bachelor"}, s: &Stats{AvgSAT: intp(1450)},
+      code: RecAddTest, test: "SAT", target: num(1450),
+    },
+    {
+      name: "bachelor's program without stats",
+      p: strong, r: Requirements{DegreeLevel: "bachelor"},
+      code: RecAddTest, test: "SAT", target: num(1400),
+    },
+    {
+      name: "unknown level suggests no test",
+      p: strong,
+    },
+    {
+      name: "gpa below minimum on the student's inverted scale",
+      p: Profile{GPA: num(2.5), GradingSystem: "de_5", IELTS: num(9), SAT: intp(1600)},
+      r: Requirements{MinGPA: num(3.3)},
+      code: RecRaiseGPA, current: num(2.5), target: num(2.0), blocking: true,
+    },
+    {
+      name: "missing gpa",
+      p: Profile{IELTS: num(9), SAT: intp(1600)},
+      r: Requirements{MinGPA: num(3.2)},
+      code: RecAddGPA, target: num(3.2), blocking: true,
+    },
+    {
+      name: "missing language",
+      p: Profile{GPA: num(4), GradingSystem: "us_4", SAT: intp(1600)},
+      r: Requirements{MinIELTS: num(6.5)},
+      code: RecAddLanguage, test: "IELTS", target: num(6.5), blocking: true,
+    },
+    {
+      name: "language below minimum on the student's test",
+      p: Profile{GPA: num(4), GradingSystem: "us_4", TOEFL: intp(80), SAT: intp(1600)},
+      r: Requirements{MinTOEFL: intp(94)},
+      code: RecRaiseLanguage, test: "TOEFL", current: num(80), target: num(94), blocking: true,
+    },
+    {
+      name: "next IELTS band",
+      p: Profile{GPA: num(4), GradingSystem: "us_4", IELTS: num(6.5), SAT: intp(1600)},
+      code: RecRaiseLanguageBand, test: "IELTS", current: num(6.5), target: num(7),
+    },
+    {
+      name: "required test below minimum, expressed in ACT",
+      p: Profile{GPA: num(4), GradingSystem: "us_4", IELTS: num(9), ACT: intp(24)},
+      r: Requirements{MinSAT: intp(1400)},
+      code: RecRaiseTest, test: "ACT", current: num(24), target: num(31), blocking: true,
+    },
+    {
+      name: "required test missing",
+      p: strong, r: Requirements{MinGMAT: intp(600), DegreeLevel: "master"},
+      code: RecAddTest, test: "GRE", target: num(315), blocking: true,
+    },
+  }
+  for _, tt := range tests {
+    recs := DefaultPolicy().Recommend(tt.p, tt.r, tt.s)
+    if tt.code == "" {
+      if rec := findRec(recs, RecAddTest, ""); rec != nil { t.Errorf("%s: unexpected %+v", tt.name, *rec) }
+      continue
+    }
+    rec := findRec(recs, tt.code, tt.test)
+    if rec == nil {
+      t.Errorf("%s: no %s %s in %+v", tt.name, tt.code, tt.test, recs)
+      continue
+    }
+    if !eqNum(rec.Current, tt.current) || !eqNum(rec.Target, tt.target) || rec.Blocking != tt.blocking {
+      t.Errorf("%s: got current=%v target=%v blocking=%v", tt.name, fmtPtr(rec.Current), fmtPtr(rec.Target), rec.Blocking)
+    }
+    for i := 1; i < len(recs); i++ {
+      if recs[i].Impact > recs[i-1].Impact { t.Errorf("%s: not sorted by impact: %+v", tt.name, recs) }
+    }
+    if tt.s != nil && !tt.s.empty() && rec.ProbabilityImpact == nil {
+      t.Errorf("%s: probability_impact missing with stats", tt.name)
+    }
+  }
+}
+
+func TestRecommendNothingToImprove(t *testing.T) {
+  p := Profile{GPA: num(4), GradingSystem: "us_4", IELTS: num(9), SAT: intp(1600),
+    Awards: strp("a, b, c"), AchievementsSummary: strp(strings.Repeat("x", 30))}
+  if recs := DefaultPolicy().Recommend(p, Requirements{DegreeLevel: "bachelor"}, nil); len(recs) != 0 {
+    t.Errorf("expected no recommendations, got %+v", recs)
+  }
+}
+
+func eqNum(a, b *float64) bool {
+  if a == nil || b == nil { return a == b }
+  return *a == *b
+}
+
+func fmtPtr(v *float64) string {
+  if v == nil { return "nil" }
+  return fmtNum(*v)
+}
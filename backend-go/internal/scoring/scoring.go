@@ -1,84 +1,190 @@
 package scoring
 
-import "math"
+import (
+  "math"
+
+  "unichance-backend-go/internal/grading"
+)
 
 type Profile struct {
   GPA *float64
   GPAScale *float64
+  GradingSystem string // grading.System коды; бос болса GPAScale бойынша анықталады
   IELTS *float64
   TOEFL *int
+  DET *int // Duolingo English Test
+  PTE *int // PTE Academic
+  Cambridge *int // Cambridge English Scale (C1 Advanced / C2 Proficiency)
   SAT *int
+  ACT *int
+  GRE *int
+  GMAT *int
+  UNT *int // ЕНТ
   BudgetYear *float64
+  BudgetCurrency string
+  Awards *string
+  AchievementsSummary *string
 }
 
 type Requirements struct {
   MinGPA *float64
+  GPASystem string // min_gpa қай шкалада; бос болса grading.Default
   MinIELTS *float64
   MinTOEFL *int
+  MinDET *int
+  MinPTE *int
+  MinCambridge *int
   MinSAT *int
+  MinACT *int
+  MinGRE *int
+  MinGMAT *int
+  MinUNT *int
+  DegreeLevel string // programs.degree_level: bachelor | master; бос болса белгісіз
 }
 
 type Result struct {
   Score int
-  Reasons []string
+  Reasons []Reason
+
+  // қай policy есептеді
+  PolicyName string
+  PolicyVersion int
+
+  // GPA 4.0 шкаласында және бағдарламаның талап шкаласында (түрлендіру мүмкін болса)
+  GPA4 *float64
+  GPAProgramScale *float64
+
+  // ең жақсы тілдік тест IELTS баламасында, SAT/ACT SAT баламасында
+  IELTSEquivalent *float64
+  SATEquivalent *int
 }
 
+// Compute scores with DefaultPolicy.
 func Compute(p Profile, r Requirements) Result {
+  return DefaultPolicy().Compute(p, r)
+}
+
+func (pol Policy) Compute(p Profile, r Requirements) Result {
+  w := pol.Weights
+  pen := pol.Penalties
   score := 0
-  reasons := []string{}
+  failed := false
+  reasons := []Reason{}
 
-  // GPA (0-40)
-  if p.GPA != nil && p.GPAScale != nil && *p.GPAScale > 0 {
-    gpaNorm := (*p.GPA) / (*p.GPAScale) // 0..1
-    part := int(math.Round(40 * clamp01(gpaNorm)))
+  // GPA (0-w.GPA)
+  var gpa4Ptr, gpaProgPtr *float64
+  if g, sys, ok := studentGPA(p); ok {
+    g4 := sys.ToGPA4(g)
+    part := int(math.Round(w.GPA * clamp01(g4/4)))
     score += part
-  } else {
-    reasons = append(reasons, "GPA көрсетілмеген, баға дәлдігі төмен")
-  }
-  if r.MinGPA != nil && p.GPA != nil && p.GPAScale != nil {
-    // compare normalized (assume req is on same scale if stored so; MVP)
-    if *p.GPA < *r.MinGPA {
-      reasons = append(reasons, "GPA талаптан төмен")
-    }
-  }
 
-  // Language (0-30)
-  langPart := 0
-  if p.IELTS != nil {
-    langPart = int(math.Round(30 * clamp01(*p.IELTS/9.0)))
-    if r.MinIELTS != nil && *p.IELTS < *r.MinIELTS {
-      reasons = append(reasons, "IELTS талаптан төмен")
-    }
-  } else if p.TOEFL != nil {
-    langPart = int(math.Round(30 * clamp01(float64(*p.TOEFL)/120.0)))
-    if r.MinTOEFL != nil && *p.TOEFL < *r.MinTOEFL {
-      reasons = append(reasons, "TOEFL талаптан төмен")
+    reqSys := requirementSystem(r)
+    gp := reqSys.FromGPA4(g4)
+    gpa4Ptr, gpaProgPtr = &g4, &gp
+
+    // салыстыру 4.0 шкаласында — инверттелген шкалаларда да дұрыс
+    if r.MinGPA != nil && g4+1e-9 < reqSys.ToGPA4(*r.MinGPA) {
+      reasons = append(reasons, Reason{
+        Code: ReasonGPABelowMin, Severity: SeverityBlocker, Factor: FactorGPA,
+        Value: num(round2(gp)), Required: num(*r.MinGPA),
+      })
+      score -= pen.BelowMinGPA
+      failed = true
     }
   } else {
-    reasons = append(reasons, "Тіл сертификаты жоқ (IELTS/TOEFL)")
+    reasons = append(reasons, Reason{Code: ReasonGPAMissing, Severity: SeverityWarning, Factor: FactorGPA})
   }
-  score += langPart
-
-  // SAT (0-20)
-  if p.SAT != nil {
-    satPart := int(math.Round(20 * clamp01(float64(*p.SAT)/1600.0)))
-    score += satPart
-    if r.MinSAT != nil && *p.SAT < *r.MinSAT {
-      reasons = append(reasons, "SAT талаптан төмен")
+
+  // Language (0-w.Language) — кез келген тілдік тест IELTS баламасына келтіріледі
+  var ieltsEq *float64
+  lang := language(p, r)
+  if lang.has {
+    v := lang.ielts
+    ieltsEq = &v
+    score += int(math.Round(w.Language * clamp01(lang.ielts/9.0)))
+    if !lang.met {
+      reasons = append(reasons, Reason{
+        Code: ReasonLanguageBelowMin, Severity: SeverityBlocker, Factor: FactorLanguage,
+        Test: lang.test, Value: num(lang.value), Required: lang.requiredIn(r, lang.test),
+      })
+      score -= pen.BelowMinLanguage
+      failed = true
     }
   } else {
-    reasons = append(reasons, "SAT көрсетілмеген (кей универге міндетті)")
+    sev := SeverityWarning
+    if lang.required != nil { sev = SeverityBlocker }
+    reasons = append(reasons, Reason{Code: ReasonLanguageMissing, Severity: sev, Factor: FactorLanguage, Required: lang.required})
+    score -= pen.MissingLanguage
+    if lang.required != nil { failed = true }
   }
 
-  // Extra (0-10) — MVP-де achievements_summary бар болса бонус
-  if true { // handler-де қосымша flag береміз
-    // placeholder
+  // Standardized tests (0-w.Tests) — SAT/ACT, GRE/GMAT, ЕНТ
+  var satEq *int
+  fams := academicTests(p, r)
+  if fams[0].has { v := fams[0].value; satEq = &v }
+  part, chosen, anyRequired := testPart(fams, w.Tests)
+  score += part
+  for _, f := range fams {
+    if f.required == nil { continue }
+    if !f.has {
+      reasons = append(reasons, Reason{
+        Code: ReasonTestRequired, Severity: SeverityBlocker, Factor: FactorTests,
+        Test: f.label, Required: num(float64(*f.required)),
+      })
+      score -= pen.MissingRequiredTest
+      failed = true
+    } else if !f.met {
+      reasons = append(reasons, Reason{
+        Code: ReasonTestBelowMin, Severity: SeverityBlocker, Factor: FactorTests,
+        Test: f.test, Value: num(float64(f.native)), Required: num(float64(f.requiredIn(r))),
+      })
+      score -= pen.BelowMinTest
+      failed = true
+    }
+  }
+  if chosen == nil && !anyRequired {
+    reasons = append(reasons, Reason{Code: ReasonTestMissing, Severity: SeverityInfo, Factor: FactorTests, Test: "SAT/ACT"})
   }
 
-  if score > 100 { score = 100 }
+  // Extra (0-w.Extra) — awards / achievements_summary бонусы
+  score += int(math.Round(pol.extra(p)))
+
+  if failed && pol.Caps.FailedRequirement > 0 && score > pol.Caps.FailedRequirement {
+    score = pol.Caps.FailedRequirement
+  }
+  max := pol.Caps.Max
+  if max <= 0 { max = 100 }
+  if score > max { score = max }
   if score < 0 { score = 0 }
 
-  return Result{Score: score, Reasons: reasons}
+  return Result{
+    Score: score, Reasons: reasons,
+    PolicyName: pol.Name, PolicyVersion: pol.Version,
+    GPA4: gpa4Ptr, GPAProgramScale: gpaProgPtr, IELTSEquivalent: ieltsEq, SATEquivalent: satEq,
+  }
+}
+
+// studentGPA resolves the student's grading system: explicit code first,
+// then the legacy numeric gpa_scale.
+func studentGPA(p Profile) (float64, grading.System, bool) {
+  if p.GPA == nil { return 0, grading.System{}, false }
+  if sys, ok := grading.Lookup(p.GradingSystem); ok {
+    return *p.GPA, sys, true
+  }
+  if p.GPAScale != nil && *p.GPAScale > 0 {
+    return *p.GPA, grading.ForScale(*p.GPAScale), true
+  }
+  return 0, grading.System{}, false
+}
+
+func requirementSystem(r Requirements) grading.System {
+  if sys, ok := grading.Lookup(r.GPASystem); ok { return sys }
+  sys, _ := grading.Lookup(grading.Default)
+  return sys
+}
+
+func round2(x float64) float64 {
+  return math.Round(x*100) / 100
 }
 
 func clamp01(x float64) float64 {
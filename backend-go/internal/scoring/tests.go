@@ -0,0 +1,194 @@
+package scoring
+
+import "math"
+
+// Тілдік және стандартты тесттерді бағалау: студенттің кез келген тесті
+// concordance арқылы бағдарламаның кез келген талабымен салыстырылады.
+
+type langTest struct {
+  name string
+  value float64 // студенттің өз балы
+  ielts float64 // студент балы IELTS баламасында
+  directMet *bool // бағдарлама дәл осы тестке min қойса
+}
+
+type langResult struct {
+  test string // студенттің ең жақсы тесті
+  value float64
+  ielts float64
+  has bool
+  required *float64 // бағдарлама қабылдайтын ең төмен IELTS баламасы
+  met bool
+}
+
+func studentLangTests(p Profile, r Requirements) []langTest {
+  out := []langTest{}
+  addInt := func(name string, v, min *int, conv func(int) float64) {
+    if v == nil { return }
+    t := langTest{name: name, value: float64(*v), ielts: conv(*v)}
+    if min != nil { m := *v >= *min; t.directMet = &m }
+    out = append(out, t)
+  }
+
+  if p.IELTS != nil {
+    t := langTest{name: "IELTS", value: *p.IELTS, ielts: *p.IELTS}
+    if r.MinIELTS != nil { m := *p.IELTS >= *r.MinIELTS; t.directMet = &m }
+    out = append(out, t)
+  }
+  addInt("TOEFL", p.TOEFL, r.MinTOEFL, TOEFLToIELTS)
+  addInt("DET", p.DET, r.MinDET, DETToIELTS)
+  addInt("PTE", p.PTE, r.MinPTE, PTEToIELTS)
+  addInt("Cambridge", p.Cambridge, r.MinCambridge, CambridgeToIELTS)
+  return out
+}
+
+func requiredIELTS(r Requirements) *float64 {
+  var req *float64
+  take := func(v float64) {
+    if req == nil || v < *req { req = &v }
+  }
+  if r.MinIELTS != nil { take(*r.MinIELTS) }
+  if r.MinTOEFL != nil { take(TOEFLToIELTS(*r.MinTOEFL)) }
+  if r.MinDET != nil { take(DETToIELTS(*r.MinDET)) }
+  if r.MinPTE != nil { take(PTEToIELTS(*r.MinPTE)) }
+  if r.MinCambridge != nil { take(CambridgeToIELTS(*r.MinCambridge)) }
+  return req
+}
+
+func language(p Profile, r Requirements) langResult {
+  tests := studentLangTests(p, r)
+  res := langResult{required: requiredIELTS(r)}
+  for _, t := range tests {
+    if !res.has || t.ielts > res.ielts {
+      res.test, res.value, res.ielts, res.has = t.name, t.value, t.ielts, true
+    }
+  }
+  if res.required == nil {
+    res.met = true
+    return res
+  }
+  for _, t := range tests {
+    if t.directMet != nil && *t.directMet {
+      res.met = true
+      return res
+    }
+  }
+  res.met = res.has && res.ielts+1e-9 >= *res.required
+  return res
+}
+
+// requiredIn expresses the program's language bar on the given test's scale:
+// the program's own minimum for that test if set, otherwise the concordance
+// of the lowest accepted IELTS equivalent.
+func (l langResult) requiredIn(r Requirements, test string) *float64 {
+  if l.required == nil { return nil }
+  f := func(v int) *float64 { x := float64(v); return &x }
+  switch test {
+  case "IELTS":
+    if r.MinIELTS != nil { return r.MinIELTS }
+    return l.required
+  case "TOEFL":
+    if r.MinTOEFL != nil { return f(*r.MinTOEFL) }
+    return f(IELTSToTOEFL(*l.required))
+  case "DET":
+    if r.MinDET != nil { return f(*r.MinDET) }
+    return f(IELTSToDET(*l.required))
+  case "PTE":
+    if r.MinPTE != nil { return f(*r.MinPTE) }
+    return f(IELTSToPTE(*l.required))
+  case "Cambridge":
+    if r.MinCambridge != nil { return f(*r.MinCambridge) }
+    return f(IELTSToCambridge(*l.required))
+  }
+  return l.required
+}
+
+// testFamily — бір-біріне түрлендірілетін тесттер тобы (SAT/ACT, GRE/GMAT, ЕНТ).
+type testFamily struct {
+  label string // топтың атауы (себептер үшін)
+  test string // студенттің ең жақсы тесті
+  value int // студент балы топтың негізгі шкаласында (SAT, GRE, ЕНТ)
+  native int // студент балы өз тестінің шкаласында (мыс. ACT)
+  norm float64 // 0..1
+  has bool
+  required *int // талап негізгі шкалада
+  met bool
+}
+
+func academicTests(p Profile, r Requirements) []testFamily {
+  sat := testFamily{label: "SAT/ACT"}
+  if p.SAT != nil { sat.test, sat.value, sat.native, sat.has = "SAT", *p.SAT, *p.SAT, true }
+  if p.ACT != nil {
+    if v := ACTToSAT(*p.ACT); !sat.has || v > sat.value { sat.test, sat.value, sat.native, sat.has = "ACT", v, *p.ACT, true }
+  }
+  sat.required = minInt(r.MinSAT, mapInt(r.MinACT, ACTToSAT))
+  sat.norm = clamp01(float64(sat.value) / 1600.0)
+
+  gre := testFamily{label: "GRE/GMAT"}
+  if p.GRE != nil { gre.test, gre.value, gre.native, gre.has = "GRE", *p.GRE, *p.GRE, true }
+  if p.GMAT != nil {
+    if v := GMATToGRE(*p.GMAT); !gre.has || v > gre.value { gre.test, gre.value, gre.native, gre.has = "GMAT", v, *p.GMAT, true }
+  }
+  gre.required = minInt(r.MinGRE, mapInt(r.MinGMAT, GMATToGRE))
+  gre.norm = clamp01(float64(gre.value-260) / 80.0)
+
+  unt := testFamily{label: "ЕНТ"}
+  if p.UNT != nil { unt.test, unt.value, unt.native, unt.has = "ЕНТ", *p.UNT, *p.UNT, true }
+  unt.required = r.MinUNT
+  unt.norm = clamp01(float64(unt.value) / 140.0)
+
+  fams := []testFamily{sat, gre, unt}
+  for i := range fams {
+    f := &fams[i]
+    f.met = f.required == nil || (f.has && f.value >= *f.required)
+  }
+  return fams
+}
+
+// requiredIn — талап студент тапсырған тесттің шкаласында.
+func (f testFamily) requiredIn(r Requirements) int {
+  switch f.test {
+  case "ACT":
+    if r.MinACT != nil { return *r.MinACT }
+    // ACTToSAT(act) >= талап болатын ең төменгі ACT
+    for act := 1; act < 36; act++ {
+      if ACTToSAT(act) >= *f.required { return act }
+    }
+    return 36
+  case "GMAT":
+    if r.MinGMAT != nil { return *r.MinGMAT }
+    for gmat := 200; gmat < 800; gmat += 10 {
+      if GMATToGRE(gmat) >= *f.required { return gmat }
+    }
+    return 800
+  }
+  return *f.required
+}
+
+// testPart picks the family used for the score: the best one the program
+// requires, otherwise the best one the student has.
+func testPart(fams []testFamily, max float64) (part int, chosen *testFamily, anyRequired bool) {
+  for i := range fams {
+    f := &fams[i]
+    if f.required != nil { anyRequired = true }
+  }
+  for i := range fams {
+    f := &fams[i]
+    if !f.has || (anyRequired && f.required == nil) { continue }
+    if chosen == nil || f.norm > chosen.norm { chosen = f }
+  }
+  if chosen == nil { return 0, nil, anyRequired }
+  return int(math.Round(max * chosen.norm)), chosen, anyRequired
+}
+
+func mapInt(v *int, f func(int) int) *int {
+  if v == nil { return nil }
+  x := f(*v)
+  return &x
+}
+
+func minInt(a, b *int) *int {
+  if a == nil { return b }
+  if b == nil || *a <= *b { return a }
+  return b
+}
@@ -0,0 +1,45 @@
+package search
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+)
+
+type Handler struct {
+	Repo Repo
+}
+
+// Suggest — GET /suggest?q=mun&types=university,city&limit=10
+func (h Handler) Suggest(c echo.Context) error {
+	limit, _ := strconv.Atoi(c.QueryParam("limit"))
+
+	var types []string
+	for _, t := range strings.Split(c.QueryParam("types"), ",") {
+		t = strings.TrimSpace(t)
+		if t == "" {
+			continue
+		}
+		if !knownType(t) {
+			return c.JSON(http.StatusBadRequest, map[string]string{"error": "unknown suggestion type: " + t})
+		}
+		types = append(types, t)
+	}
+
+	items, err := h.Repo.Suggest(c.Request().Context(), c.QueryParam("q"), types, limit)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+	}
+	return c.JSON(http.StatusOK, map[string]any{"items": items})
+}
+
+func knownType(t string) bool {
+	for _, k := range AllTypes {
+		if k == t {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,170 @@
+package search
+
+import (
+	"context"
+	"strings"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// Suggestion types.
+const (
+	TypeUniversity = "university"
+	TypeProgram    = "program"
+	TypeField      = "field"
+	TypeCity       = "city"
+	TypeCountry    = "country"
+)
+
+var AllTypes = []string{TypeUniversity, TypeProgram, TypeField, TypeCity, TypeCountry}
+
+const (
+	DefaultLimit = 10
+	MaxLimit     = 20
+)
+
+// Suggestion — typeahead жолы. Value — GET /programs фильтріне берілетін мән
+// (university үшін атауы, country үшін коды); Label — көрсетілетін мәтін
+// (мысалы "Мюнхен" → Value "Munich").
+type Suggestion struct {
+	Type       string  `json:"type"`
+	Value      string  `json:"value"`
+	Label      string  `json:"label"`
+	ID         *string `json:"id,omitempty"` // university id
+	Programs   int     `json:"programs"`     // сәйкес бағдарламалар саны
+	Popularity int     `json:"popularity"`   // соңғы 90 күндегі score тексерістері
+	QSRank     *int    `json:"qs_rank,omitempty"`
+}
+
+type Repo struct {
+	DB *pgxpool.Pool
+}
+
+// Suggest returns mixed suggestions whose text (or a synonym of it) starts
+// with q at a word boundary. Prefix matches on the first word come first,
+// then popularity, programs count and qs_rank.
+func (r Repo) Suggest(ctx context.Context, q string, types []string, limit int) ([]Suggestion, error) {
+	q = strings.TrimSpace(q)
+	out := []Suggestion{}
+	if q == "" {
+		return out, nil
+	}
+	if limit <= 0 {
+		limit = DefaultLimit
+	}
+	if limit > MaxLimit {
+		limit = MaxLimit
+	}
+	if len(types) == 0 {
+		types = AllTypes
+	}
+
+	esc := escapeLike(q)
+	prefix := esc + "%"      // "mun%"
+	word := "% " + esc + "%" // "technical university of mun%"
+
+	rows, err := r.DB.Query(ctx, `
+    WITH syn AS (
+      -- q-ға сәйкес келетін city/country синонимдерінің канондық мәндері
+      SELECT s2.term AS canon, MIN(s1.term) AS label, bool_or(s1.term ILIKE $1) AS head
+      FROM search_synonyms s1
+      JOIN search_synonyms s2 ON s2.group_key = s1.group_key AND s2.kind = s1.kind
+      WHERE s1.kind IN ('city','country') AND (s1.term ILIKE $1 OR s1.term ILIKE $2)
+      GROUP BY s2.term
+    ),
+    cand AS (
+      -- алдымен q-ға сәйкес бағдарламалар ғана; popularity тек солар үшін саналады
+      SELECT programs.id, programs.title, programs.field,
+        universities.id AS uni_id, universities.name AS uni_name,
+        universities.city, universities.country_code, universities.qs_rank
+      FROM programs
+      JOIN universities ON universities.id = programs.university_id
+      WHERE universities.name ILIKE $1 OR universities.name ILIKE $2
+        OR programs.title ILIKE $1 OR programs.title ILIKE $2
+        OR programs.field ILIKE $1 OR programs.field ILIKE $2
+        OR universities.city ILIKE $1 OR universities.city ILIKE $2
+        OR universities.country_code ILIKE $1
+        OR EXISTS (
+          SELECT 1 FROM syn
+          WHERE lower(syn.canon) = lower(universities.city) OR upper(syn.canon) = universities.country_code
+        )
+    ),
+    pop AS (
+      SELECT program_id, COUNT(*)::int AS n
+      FROM scores
+      WHERE program_id IN (SELECT id FROM cand) AND created_at > now() - interval '90 days'
+      GROUP BY program_id
+    ),
+    p AS (
+      SELECT cand.*, COALESCE(pop.n, 0) AS pop
+      FROM cand
+      LEFT JOIN pop ON pop.program_id = cand.id
+    ),
+    s AS (
+      SELECT 'university' AS type, u.name AS value, u.name AS label, u.id::text AS id,
+        COUNT(p.id)::int AS programs, COALESCE(SUM(p.pop), 0)::int AS popularity, u.qs_rank,
+        u.name ILIKE $1 AS head
+      FROM universities u
+      LEFT JOIN p ON p.uni_id = u.id
+      WHERE u.name ILIKE $1 OR u.name ILIKE $2
+      GROUP BY u.id
+
+      UNION ALL
+      SELECT 'program', p.title, p.title, NULL,
+        COUNT(*)::int, SUM(p.pop)::int, MIN(p.qs_rank),
+        p.title ILIKE $1
+      FROM p
+      WHERE p.title ILIKE $1 OR p.title ILIKE $2
+      GROUP BY p.title
+
+      UNION ALL
+      SELECT 'field', p.field, p.field, NULL,
+        COUNT(*)::int, SUM(p.pop)::int, MIN(p.qs_rank),
+        p.field ILIKE $1
+      FROM p
+      WHERE p.field ILIKE $1 OR p.field ILIKE $2
+      GROUP BY p.field
+
+      UNION ALL
+      SELECT 'city', p.city, COALESCE(MIN(m.label), p.city), NULL,
+        COUNT(*)::int, SUM(p.pop)::int, MIN(p.qs_rank),
+        p.city ILIKE $1 OR COALESCE(bool_or(m.head), false)
+      FROM p
+      LEFT JOIN LATERAL (SELECT MIN(syn.label) AS label, bool_or(syn.head) AS head FROM syn WHERE lower(syn.canon) = lower(p.city)) m ON true
+      WHERE p.city ILIKE $1 OR p.city ILIKE $2 OR m.label IS NOT NULL
+      GROUP BY p.city
+
+      UNION ALL
+      SELECT 'country', p.country_code, COALESCE(MIN(m.label), p.country_code), NULL,
+        COUNT(*)::int, SUM(p.pop)::int, MIN(p.qs_rank),
+        p.country_code ILIKE $1 OR COALESCE(bool_or(m.head), false)
+      FROM p
+      LEFT JOIN LATERAL (SELECT MIN(syn.label) AS label, bool_or(syn.head) AS head FROM syn WHERE upper(syn.canon) = p.country_code) m ON true
+      WHERE p.country_code ILIKE $1 OR m.label IS NOT NULL
+      GROUP BY p.country_code
+    )
+    SELECT type, value, label, id, programs, popularity, qs_rank
+    FROM s
+    WHERE type = ANY($3) AND value IS NOT NULL
+    ORDER BY head DESC, popularity DESC, programs DESC, qs_rank ASC NULLS LAST, value ASC
+    LIMIT $4
+  `, prefix, word, types, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var s Suggestion
+		if err := rows.Scan(&s.Type, &s.Value, &s.Label, &s.ID, &s.Programs, &s.Popularity, &s.QSRank); err != nil {
+			return nil, err
+		}
+		out = append(out, s)
+	}
+	return out, rows.Err()
+}
+
+// escapeLike escapes LIKE wildcards so "100%" is matched literally.
+func escapeLike(s string) string {
+	return strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(s)
+}
@@ -1,9 +1,14 @@
 package universities
 
 import (
+	"errors"
 	"net/http"
+	"strconv"
+	"strings"
 
 	"github.com/labstack/echo/v4"
+
+	"unichance-backend-go/internal/keyset"
 )
 
 type Handler struct {
@@ -21,3 +26,76 @@ func (h Handler) GetByID(c echo.Context) error {
 	}
 	return c.JSON(http.StatusOK, u)
 }
+
+// List — GET /universities?countries=DE&levels=master&max_qs=200&sort=qs&cursor=...&total=false
+func (h Handler) List(c echo.Context) error {
+	page, _ := strconv.Atoi(c.QueryParam("page"))
+	limit, _ := strconv.Atoi(c.QueryParam("limit"))
+
+	var hasPrograms *bool
+	if v := c.QueryParam("has_programs"); v != "" {
+		b := v == "true"
+		hasPrograms = &b
+	}
+
+	params := ListParams{
+		Q:           c.QueryParam("q"),
+		Countries:   splitCSV(c.QueryParam("countries")),
+		Cities:      splitCSV(c.QueryParam("cities")),
+		MinQS:       intParam(c, "min_qs"),
+		MaxQS:       intParam(c, "max_qs"),
+		MinTHE:      intParam(c, "min_the"),
+		MaxTHE:      intParam(c, "max_the"),
+		Levels:      splitCSV(c.QueryParam("levels")),
+		HasPrograms: hasPrograms,
+		Sort:        c.QueryParam("sort"),
+		Page:        page,
+		Limit:       limit,
+		Cursor:      c.QueryParam("cursor"),
+		SkipTotal:   c.QueryParam("total") == "false",
+	}
+	params.Normalize()
+
+	res, err := h.Repo.List(c.Request().Context(), params)
+	if errors.Is(err, keyset.ErrBadCursor) {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+	}
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+	}
+
+	resp := map[string]any{
+		"page":        params.Page,
+		"limit":       params.Limit,
+		"items":       res.Items,
+		"next_cursor": res.NextCursor,
+		"prev_cursor": res.PrevCursor,
+	}
+	if res.Total != nil {
+		resp["total"] = *res.Total
+	}
+	return c.JSON(http.StatusOK, resp)
+}
+
+func splitCSV(s string) []string {
+	if strings.TrimSpace(s) == "" {
+		return nil
+	}
+	parts := strings.Split(s, ",")
+	out := make([]string, 0, len(parts))
+	for _, p := range parts {
+		p = strings.TrimSpace(p)
+		if p != "" {
+			out = append(out, p)
+		}
+	}
+	return out
+}
+
+func intParam(c echo.Context, name string) *int {
+	v, err := strconv.Atoi(c.QueryParam(name))
+	if err != nil {
+		return nil
+	}
+	return &v
+}
@@ -0,0 +1,229 @@
+package universities
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"unichance-backend-go/internal/keyset"
+)
+
+type ListParams struct {
+	Q           string   `json:"q"`
+	Countries   []string `json:"countries"`
+	Cities      []string `json:"cities"`
+	MinQS       *int     `json:"min_qs"`
+	MaxQS       *int     `json:"max_qs"`
+	MinTHE      *int     `json:"min_the"`
+	MaxTHE      *int     `json:"max_the"`
+	Levels      []string `json:"levels"`       // осы деңгейде кемінде бір бағдарламасы бар
+	HasPrograms *bool    `json:"has_programs"` // true: бағдарламасы бар; false: жоқ
+	Sort        string   `json:"sort"`         // qs (default) | the | name | programs
+	Page        int      `json:"page"`         // cursor жоқ кезде ғана (OFFSET)
+	Limit       int      `json:"limit"`
+	Cursor      string   `json:"cursor"`     // алдыңғы жауаптың next_cursor/prev_cursor
+	SkipTotal   bool     `json:"skip_total"` // COUNT(*) есептелмейді
+}
+
+// ListResult — бір бет. Total SkipTotal кезінде nil.
+type ListResult struct {
+	Items      []UniversityCard
+	Total      *int
+	NextCursor string
+	PrevCursor string
+}
+
+// UniversityCard — GET /universities тізім жолы.
+type UniversityCard struct {
+	ID          string  `json:"id"`
+	Name        string  `json:"name"`
+	CountryCode string  `json:"country_code"`
+	City        *string `json:"city,omitempty"`
+	Website     *string `json:"website,omitempty"`
+	QSRank      *int    `json:"qs_rank,omitempty"`
+	THERank     *int    `json:"the_rank,omitempty"`
+
+	Programs        int            `json:"programs"`
+	ProgramsByLevel map[string]int `json:"programs_by_level"`
+}
+
+// Normalize applies default and maximum page/limit; List calls it, handlers
+// call it too so the response echoes what was actually used.
+func (p *ListParams) Normalize() {
+	if p.Page <= 0 {
+		p.Page = 1
+	}
+	if p.Limit <= 0 {
+		p.Limit = 20
+	}
+	if p.Limit > 50 {
+		p.Limit = 50
+	}
+}
+
+var (
+	keyQS   = keyset.Key{Expr: "COALESCE(universities.qs_rank, " + keyset.NoRank + ")", Kind: "int"}
+	keyTHE  = keyset.Key{Expr: "COALESCE(universities.the_rank, " + keyset.NoRank + ")", Kind: "int"}
+	keyName = keyset.Key{Expr: "universities.name", Kind: "text"}
+	keyID   = keyset.Key{Expr: "universities.id", Kind: "uuid"} // тай-брейкер
+)
+
+// sortKeys returns the canonical sort name and its keyset keys.
+func sortKeys(sort string) (string, []keyset.Key) {
+	switch sort {
+	case "the":
+		return sort, []keyset.Key{keyTHE, keyQS, keyName, keyID}
+	case "name":
+		return sort, []keyset.Key{keyName, keyID}
+	case "programs":
+		return sort, []keyset.Key{{Expr: "(-pc.total)::int8", Kind: "int"}, keyQS, keyName, keyID}
+	}
+	return "qs", []keyset.Key{keyQS, keyTHE, keyName, keyID}
+}
+
+func filterHash(p ListParams) string {
+	p.Cursor, p.Page, p.Limit, p.SkipTotal = "", 0, 0, false
+	return keyset.Hash(p)
+}
+
+// List pages through universities with keyset cursors, like programs.List.
+// Without a cursor, Page > 1 still falls back to OFFSET.
+func (r Repo) List(ctx context.Context, p ListParams) (ListResult, error) {
+	p.Normalize()
+
+	where := []string{"1=1"}
+	args := []any{}
+	add := func(cond string, val any) {
+		args = append(args, val)
+		where = append(where, fmt.Sprintf(cond, len(args)))
+	}
+
+	if q := strings.TrimSpace(p.Q); q != "" {
+		args = append(args, "%"+escapeLike(q)+"%", q)
+		where = append(where, fmt.Sprintf("(universities.name ILIKE $%d OR $%d <%% universities.name)", len(args)-1, len(args)))
+	}
+	if len(p.Countries) > 0 {
+		add("universities.country_code = ANY($%d)", p.Countries)
+	}
+	if len(p.Cities) > 0 {
+		add("lower(universities.city) = ANY(SELECT lower(c) FROM unnest($%d::text[]) c)", p.Cities)
+	}
+	if p.MinQS != nil {
+		add("universities.qs_rank >= $%d", *p.MinQS)
+	}
+	if p.MaxQS != nil {
+		add("universities.qs_rank <= $%d", *p.MaxQS)
+	}
+	if p.MinTHE != nil {
+		add("universities.the_rank >= $%d", *p.MinTHE)
+	}
+	if p.MaxTHE != nil {
+		add("universities.the_rank <= $%d", *p.MaxTHE)
+	}
+	if len(p.Levels) > 0 {
+		add("EXISTS (SELECT 1 FROM programs WHERE programs.university_id = universities.id AND programs.degree_level::text = ANY($%d))", p.Levels)
+	}
+	if p.HasPrograms != nil {
+		add("EXISTS (SELECT 1 FROM programs WHERE programs.university_id = universities.id) = $%d", *p.HasPrograms)
+	}
+
+	whereSQL := strings.Join(where, " AND ")
+
+	var res ListResult
+	if !p.SkipTotal {
+		var total int
+		countSQL := `
+    SELECT COUNT(*)
+    FROM universities
+    WHERE ` + whereSQL
+		if err := r.DB.QueryRow(ctx, countSQL, args...).Scan(&total); err != nil {
+			return ListResult{}, err
+		}
+		res.Total = &total
+	}
+
+	// sort + keyset
+	sortName, keys := sortKeys(p.Sort)
+	fhash := filterHash(p)
+	var cur keyset.Cursor
+	offset := 0
+	if p.Cursor != "" {
+		var err error
+		cur, err = keyset.Decode(p.Cursor, sortName, fhash, keys)
+		if err != nil {
+			return ListResult{}, err
+		}
+		whereSQL += " AND " + keyset.Where(keys, cur.Back, len(args)+1)
+		args = append(args, cur.Keys...)
+	} else {
+		offset = (p.Page - 1) * p.Limit
+	}
+
+	// бір артық жол келесі (кері бағытта — алдыңғы) беттің барын көрсетеді
+	args = append(args, p.Limit+1, offset)
+	limitPos := len(args) - 1
+	offsetPos := len(args)
+
+	itemsSQL := `
+    SELECT
+      universities.id, universities.name, universities.country_code, universities.city, universities.website,
+      universities.qs_rank, universities.the_rank,
+      pc.total, pc.by_level,
+      ` + strings.Join(keyset.Exprs(keys), ", ") + `
+    FROM universities
+    LEFT JOIN LATERAL (
+      SELECT COALESCE(SUM(n), 0)::int AS total,
+        COALESCE(jsonb_object_agg(level, n), '{}'::jsonb) AS by_level
+      FROM (
+        SELECT programs.degree_level::text AS level, COUNT(*)::int AS n
+        FROM programs
+        WHERE programs.university_id = universities.id
+        GROUP BY 1
+      ) l
+    ) pc ON true
+    WHERE ` + whereSQL + `
+    ORDER BY ` + keyset.Order(keys, cur.Back) + `
+    LIMIT $` + fmt.Sprint(limitPos) + ` OFFSET $` + fmt.Sprint(offsetPos)
+
+	rows, err := r.DB.Query(ctx, itemsSQL, args...)
+	if err != nil {
+		return ListResult{}, err
+	}
+	defer rows.Close()
+
+	res.Items = []UniversityCard{}
+	var rowKeys [][]any
+	for rows.Next() {
+		var u UniversityCard
+		kd := keyset.Dest(keys)
+		dest := append([]any{
+			&u.ID, &u.Name, &u.CountryCode, &u.City, &u.Website,
+			&u.QSRank, &u.THERank,
+			&u.Programs, &u.ProgramsByLevel,
+		}, kd...)
+		if err := rows.Scan(dest...); err != nil {
+			return ListResult{}, err
+		}
+		res.Items = append(res.Items, u)
+		rowKeys = append(rowKeys, keyset.Values(kd))
+	}
+	if err := rows.Err(); err != nil {
+		return ListResult{}, err
+	}
+
+	more := len(res.Items) > p.Limit
+	if more {
+		res.Items, rowKeys = res.Items[:p.Limit], rowKeys[:p.Limit]
+	}
+	if cur.Back {
+		keyset.Reverse(res.Items)
+		keyset.Reverse(rowKeys)
+	}
+	res.NextCursor, res.PrevCursor = keyset.Links(cur, sortName, fhash, rowKeys, more, p.Cursor != "" || offset > 0)
+	return res, nil
+}
+
+// escapeLike escapes LIKE wildcards so "100%" is matched literally.
+func escapeLike(s string) string {
+	return strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(s)
+}
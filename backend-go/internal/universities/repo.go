@@ -30,33 +30,10 @@ func (r Repo) GetByID(ctx context.Context, id string) (*University, error) {
 	}
 
 	// links
-	rows, err := r.DB.Query(ctx, `
-    SELECT
-      ul.id, ul.link_type, ul.url, ul.title, ul.is_official, ul.priority,
-      s.code as source_code,
-      ul.last_verified_at
-    FROM university_links ul
-    LEFT JOIN sources s ON s.id = ul.source_id
-    WHERE ul.university_id = $1
-    ORDER BY ul.is_official DESC, ul.priority ASC, ul.link_type ASC
-  `, id)
+	u.Links, err = r.Links(ctx, id)
 	if err != nil {
 		return nil, err
 	}
-	defer rows.Close()
-
-	u.Links = []UniversityLink{}
-	for rows.Next() {
-		var l UniversityLink
-		if err := rows.Scan(
-			&l.ID, &l.LinkType, &l.URL, &l.Title, &l.IsOfficial, &l.Priority,
-			&l.SourceCode,
-			&l.LastVerifiedAt,
-		); err != nil {
-			return nil, err
-		}
-		u.Links = append(u.Links, l)
-	}
 
 	// programs (lite)
 	prow, err := r.DB.Query(ctx, `
@@ -89,3 +66,35 @@ func (r Repo) GetByID(ctx context.Context, id string) (*University, error) {
 
 	return &u, nil
 }
+
+// Links returns the university's links, official and high-priority first.
+func (r Repo) Links(ctx context.Context, universityID string) ([]UniversityLink, error) {
+	rows, err := r.DB.Query(ctx, `
+    SELECT
+      ul.id, ul.link_type, ul.url, ul.title, ul.is_official, ul.priority,
+      s.code as source_code,
+      ul.last_verified_at
+    FROM university_links ul
+    LEFT JOIN sources s ON s.id = ul.source_id
+    WHERE ul.university_id = $1
+    ORDER BY ul.is_official DESC, ul.priority ASC, ul.link_type ASC
+  `, universityID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	links := []UniversityLink{}
+	for rows.Next() {
+		var l UniversityLink
+		if err := rows.Scan(
+			&l.ID, &l.LinkType, &l.URL, &l.Title, &l.IsOfficial, &l.Priority,
+			&l.SourceCode,
+			&l.LastVerifiedAt,
+		); err != nil {
+			return nil, err
+		}
+		links = append(links, l)
+	}
+	return links, rows.Err()
+}
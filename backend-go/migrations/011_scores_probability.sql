@@ -0,0 +1,12 @@
+-- 011_scores_probability.sql
+-- "probability" scoring mode: қабылдану ықтималдығы + reach/target/safety категориясы
+
+ALTER TABLE scores
+  ADD COLUMN IF NOT EXISTS probability INT CHECK (probability IS NULL OR (probability >= 0 AND probability <= 100)),
+  ADD COLUMN IF NOT EXISTS category TEXT,
+  ADD COLUMN IF NOT EXISTS model TEXT;
+
+ALTER TABLE scores
+  DROP CONSTRAINT IF EXISTS chk_scores_category;
+ALTER TABLE scores
+  ADD CONSTRAINT chk_scores_category CHECK (category IS NULL OR category IN ('reach','target','safety'));
@@ -0,0 +1,42 @@
+-- 012_admission_stats.sql
+-- Бағдарлама бойынша жылдық қабылдау статистикасы (legacy admission_stats баламасы).
+-- Ықтималдық моделі (scoring.Predict) және "typical admitted student" блогы осыны қолданады.
+
+BEGIN;
+
+CREATE TABLE IF NOT EXISTS admission_stats (
+  id              UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+  program_id      UUID NOT NULL REFERENCES programs(id) ON DELETE CASCADE,
+  source_id       UUID REFERENCES sources(id) ON DELETE SET NULL,
+
+  year            INT NOT NULL CHECK (year BETWEEN 1990 AND 2100),
+  applicants      INT CHECK (applicants IS NULL OR applicants >= 0),
+  admitted        INT CHECK (admitted IS NULL OR admitted >= 0),
+  enrolled        INT CHECK (enrolled IS NULL OR enrolled >= 0),
+
+  -- пайызбен (0..100); бос болса applicants/admitted-тен есептеледі
+  acceptance_rate NUMERIC(5,2) CHECK (acceptance_rate IS NULL OR acceptance_rate BETWEEN 0 AND 100),
+
+  -- қабылданған студенттердің орташа көрсеткіштері (GPA 4.0 шкаласында)
+  avg_gpa         NUMERIC(3,2),
+  avg_ielts       NUMERIC(2,1),
+  avg_toefl       INT,
+  avg_sat         INT,
+
+  notes           TEXT,
+
+  created_at      TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+  updated_at      TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+
+  CONSTRAINT uq_admission_stats_program_year UNIQUE (program_id, year)
+);
+
+CREATE INDEX IF NOT EXISTS idx_admission_stats_program ON admission_stats(program_id);
+CREATE INDEX IF NOT EXISTS idx_admission_stats_year ON admission_stats(year);
+
+DROP TRIGGER IF EXISTS trg_admission_stats_updated_at ON admission_stats;
+CREATE TRIGGER trg_admission_stats_updated_at
+BEFORE UPDATE ON admission_stats
+FOR EACH ROW EXECUTE FUNCTION set_updated_at();
+
+COMMIT;
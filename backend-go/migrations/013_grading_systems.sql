@@ -0,0 +1,11 @@
+-- 013_grading_systems.sql
+-- GPA-ны ұлттық бағалау жүйелерінен 4.0 шкаласына түрлендіру үшін жүйе коды
+-- (internal/grading регистріндегі кодтар: us_4, pct_100, kz_5, ru_5, de_5, uk_pct, uk_class, ib_45, scale_5).
+
+-- студенттің GPA-сы қай жүйеде; NULL болса gpa_scale бойынша анықталады
+ALTER TABLE profiles
+  ADD COLUMN IF NOT EXISTS grading_system TEXT;
+
+-- requirements.min_gpa қай жүйеде; NULL болса us_4
+ALTER TABLE requirements
+  ADD COLUMN IF NOT EXISTS gpa_system TEXT;
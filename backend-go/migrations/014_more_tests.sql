@@ -0,0 +1,22 @@
+-- 014_more_tests.sql
+-- Қосымша стандартты тесттер: ACT, GRE, GMAT, Duolingo (DET), PTE Academic,
+-- Cambridge C1/C2 (Cambridge English Scale), ЕНТ (UNT).
+-- Тесттер арасындағы concordance internal/scoring/concordance.go ішінде.
+
+ALTER TABLE profiles
+  ADD COLUMN IF NOT EXISTS duolingo INT CHECK (duolingo IS NULL OR duolingo BETWEEN 10 AND 160),
+  ADD COLUMN IF NOT EXISTS pte INT CHECK (pte IS NULL OR pte BETWEEN 10 AND 90),
+  ADD COLUMN IF NOT EXISTS cambridge INT CHECK (cambridge IS NULL OR cambridge BETWEEN 80 AND 230),
+  ADD COLUMN IF NOT EXISTS act INT CHECK (act IS NULL OR act BETWEEN 1 AND 36),
+  ADD COLUMN IF NOT EXISTS gre INT CHECK (gre IS NULL OR gre BETWEEN 260 AND 340),
+  ADD COLUMN IF NOT EXISTS gmat INT CHECK (gmat IS NULL OR gmat BETWEEN 200 AND 805),
+  ADD COLUMN IF NOT EXISTS unt INT CHECK (unt IS NULL OR unt BETWEEN 0 AND 140);
+
+ALTER TABLE requirements
+  ADD COLUMN IF NOT EXISTS min_duolingo INT,
+  ADD COLUMN IF NOT EXISTS min_pte INT,
+  ADD COLUMN IF NOT EXISTS min_cambridge INT,
+  ADD COLUMN IF NOT EXISTS min_act INT,
+  ADD COLUMN IF NOT EXISTS min_gre INT,
+  ADD COLUMN IF NOT EXISTS min_gmat INT,
+  ADD COLUMN IF NOT EXISTS min_unt INT;
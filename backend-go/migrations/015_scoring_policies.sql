@@ -0,0 +1,38 @@
+-- 015_scoring_policies.sql
+-- Атаулы, нұсқаланған scoring policy-лер (салмақтар, шектеулер, айыппұлдар, бонустар).
+-- config құрылымы scoring.Policy JSON-ына сәйкес. Әр атау бойынша тек бір active нұсқа.
+
+BEGIN;
+
+CREATE TABLE IF NOT EXISTS scoring_policies (
+  id          UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+  name        TEXT NOT NULL,
+  version     INT NOT NULL CHECK (version > 0),
+  description TEXT,
+  config      JSONB NOT NULL,
+  is_active   BOOLEAN NOT NULL DEFAULT FALSE,
+  created_at  TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+
+  CONSTRAINT uq_scoring_policies_name_version UNIQUE (name, version)
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS uq_scoring_policies_active
+  ON scoring_policies(name) WHERE is_active;
+
+INSERT INTO scoring_policies(name, version, description, is_active, config)
+VALUES ('default', 1, 'GPA 40 / language 30 / tests 20 / extra 10', TRUE, '{
+  "weights":   {"gpa": 40, "language": 30, "tests": 20, "extra": 10},
+  "caps":      {"max": 100, "failed_requirement": 0},
+  "penalties": {"below_min_gpa": 0, "below_min_language": 0, "below_min_test": 0, "missing_language": 0, "missing_required_test": 0},
+  "bonuses":   {"per_award": 2, "max_awards": 3, "achievements": 4, "achievements_min_len": 20}
+}'::jsonb)
+ON CONFLICT (name, version) DO NOTHING;
+
+-- scores: қай policy нұсқасы есептегені
+ALTER TABLE scores
+  ADD COLUMN IF NOT EXISTS policy_name TEXT,
+  ADD COLUMN IF NOT EXISTS policy_version INT;
+
+CREATE INDEX IF NOT EXISTS idx_scores_policy ON scores(policy_name, policy_version);
+
+COMMIT;
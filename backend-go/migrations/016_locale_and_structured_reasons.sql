@@ -0,0 +1,15 @@
+-- 016_locale_and_structured_reasons.sql
+-- Жауап тілі (kk/ru/en) пайдаланушы таңдауы бойынша.
+-- scores.reasons енді құрылымдық объектілер: {"code","severity","factor","test","value","required"}.
+
+ALTER TABLE profiles
+  ADD COLUMN IF NOT EXISTS locale TEXT CHECK (locale IS NULL OR locale IN ('kk','ru','en'));
+
+-- ескі жолдар: мәтіндік себептерді {"code":"legacy","message":...} түріне келтіру
+UPDATE scores
+SET reasons = (
+  SELECT COALESCE(jsonb_agg(jsonb_build_object('code','legacy','severity','info','factor','','message', r)), '[]'::jsonb)
+  FROM jsonb_array_elements_text(scores.reasons) AS r
+)
+WHERE jsonb_typeof(reasons) = 'array'
+  AND EXISTS (SELECT 1 FROM jsonb_array_elements(scores.reasons) e WHERE jsonb_typeof(e) = 'string');
@@ -0,0 +1,26 @@
+-- 017_city_living_costs.sql
+-- Қала бойынша жылдық тұру шығыны (жатақхана/пәтер, тамақ, көлік). city IS NULL — ел бойынша орташа.
+-- Affordability (scoring.Afford) include_living=true болғанда қолданады.
+
+BEGIN;
+
+CREATE TABLE IF NOT EXISTS city_living_costs (
+  id           UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+  country_code TEXT NOT NULL,
+  city         TEXT,
+  amount_year  NUMERIC NOT NULL CHECK (amount_year >= 0),
+  currency     tuition_currency NOT NULL,
+  source_id    UUID REFERENCES sources(id) ON DELETE SET NULL,
+  created_at   TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+  updated_at   TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS uq_city_living_costs
+  ON city_living_costs(country_code, lower(COALESCE(city, '')));
+
+DROP TRIGGER IF EXISTS trg_city_living_costs_updated_at ON city_living_costs;
+CREATE TRIGGER trg_city_living_costs_updated_at
+BEFORE UPDATE ON city_living_costs
+FOR EACH ROW EXECUTE FUNCTION set_updated_at();
+
+COMMIT;
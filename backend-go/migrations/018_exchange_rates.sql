@@ -0,0 +1,24 @@
+-- 018_exchange_rates.sql
+-- Күнделікті валюта бағамдары: 1 base = rate quote (rate_date күні).
+-- Көзі: seed/exchange_rates.csv немесе ECB eurofxref XML (go run ./cmd/rates).
+
+BEGIN;
+
+CREATE TABLE IF NOT EXISTS exchange_rates (
+  id         UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+  rate_date  DATE NOT NULL,
+  base       TEXT NOT NULL CHECK (base ~ '^[A-Z]{3}$'),
+  quote      TEXT NOT NULL CHECK (quote ~ '^[A-Z]{3}$'),
+  rate       NUMERIC(20,8) NOT NULL CHECK (rate > 0),
+  source     TEXT NOT NULL DEFAULT 'manual',
+  created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+  CHECK (base <> quote)
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS uq_exchange_rates_day
+  ON exchange_rates(base, quote, rate_date);
+
+CREATE INDEX IF NOT EXISTS idx_exchange_rates_date
+  ON exchange_rates(rate_date DESC);
+
+COMMIT;
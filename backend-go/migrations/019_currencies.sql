@@ -0,0 +1,86 @@
+-- 019_currencies.sql
+-- ISO 4217 валюталар анықтамалығы. tuition_currency enum (USD/EUR/KZT) орнына
+-- programs.tuition_currency, profiles.budget_currency, city_living_costs.currency
+-- TEXT + FK currencies(code) болады; enum жойылады.
+
+BEGIN;
+
+CREATE TABLE IF NOT EXISTS currencies (
+  code         TEXT PRIMARY KEY CHECK (code ~ '^[A-Z]{3}$'),
+  numeric_code TEXT CHECK (numeric_code IS NULL OR numeric_code ~ '^[0-9]{3}$'),
+  name         TEXT NOT NULL,
+  minor_units  SMALLINT NOT NULL DEFAULT 2 CHECK (minor_units BETWEEN 0 AND 4),
+  is_active    BOOLEAN NOT NULL DEFAULT true
+);
+
+INSERT INTO currencies(code, numeric_code, name, minor_units) VALUES
+  ('AED','784','UAE Dirham',2),
+  ('AMD','051','Armenian Dram',2),
+  ('AUD','036','Australian Dollar',2),
+  ('AZN','944','Azerbaijan Manat',2),
+  ('BGN','975','Bulgarian Lev',2),
+  ('BRL','986','Brazilian Real',2),
+  ('BYN','933','Belarusian Ruble',2),
+  ('CAD','124','Canadian Dollar',2),
+  ('CHF','756','Swiss Franc',2),
+  ('CNY','156','Yuan Renminbi',2),
+  ('CZK','203','Czech Koruna',2),
+  ('DKK','208','Danish Krone',2),
+  ('EGP','818','Egyptian Pound',2),
+  ('EUR','978','Euro',2),
+  ('GBP','826','Pound Sterling',2),
+  ('GEL','981','Lari',2),
+  ('HKD','344','Hong Kong Dollar',2),
+  ('HUF','348','Forint',2),
+  ('IDR','360','Rupiah',2),
+  ('ILS','376','New Israeli Sheqel',2),
+  ('INR','356','Indian Rupee',2),
+  ('ISK','352','Iceland Krona',0),
+  ('JPY','392','Yen',0),
+  ('KGS','417','Som',2),
+  ('KRW','410','Won',0),
+  ('KZT','398','Tenge',2),
+  ('MDL','498','Moldovan Leu',2),
+  ('MXN','484','Mexican Peso',2),
+  ('MYR','458','Malaysian Ringgit',2),
+  ('NOK','578','Norwegian Krone',2),
+  ('NZD','554','New Zealand Dollar',2),
+  ('PHP','608','Philippine Peso',2),
+  ('PLN','985','Zloty',2),
+  ('QAR','634','Qatari Rial',2),
+  ('RON','946','Romanian Leu',2),
+  ('RSD','941','Serbian Dinar',2),
+  ('RUB','643','Russian Ruble',2),
+  ('SAR','682','Saudi Riyal',2),
+  ('SEK','752','Swedish Krona',2),
+  ('SGD','702','Singapore Dollar',2),
+  ('THB','764','Baht',2),
+  ('TJS','972','Somoni',2),
+  ('TMT','934','Turkmenistan New Manat',2),
+  ('TRY','949','Turkish Lira',2),
+  ('TWD','901','New Taiwan Dollar',2),
+  ('UAH','980','Hryvnia',2),
+  ('USD','840','US Dollar',2),
+  ('UZS','860','Uzbekistan Sum',2),
+  ('ZAR','710','Rand',2)
+ON CONFLICT (code) DO NOTHING;
+
+-- enum → TEXT + FK
+ALTER TABLE programs ALTER COLUMN tuition_currency TYPE TEXT USING tuition_currency::text;
+ALTER TABLE programs DROP CONSTRAINT IF EXISTS fk_programs_tuition_currency;
+ALTER TABLE programs ADD CONSTRAINT fk_programs_tuition_currency
+  FOREIGN KEY (tuition_currency) REFERENCES currencies(code) ON UPDATE CASCADE;
+
+ALTER TABLE profiles ALTER COLUMN budget_currency TYPE TEXT USING budget_currency::text;
+ALTER TABLE profiles DROP CONSTRAINT IF EXISTS fk_profiles_budget_currency;
+ALTER TABLE profiles ADD CONSTRAINT fk_profiles_budget_currency
+  FOREIGN KEY (budget_currency) REFERENCES currencies(code) ON UPDATE CASCADE;
+
+ALTER TABLE city_living_costs ALTER COLUMN currency TYPE TEXT USING currency::text;
+ALTER TABLE city_living_costs DROP CONSTRAINT IF EXISTS fk_city_living_costs_currency;
+ALTER TABLE city_living_costs ADD CONSTRAINT fk_city_living_costs_currency
+  FOREIGN KEY (currency) REFERENCES currencies(code) ON UPDATE CASCADE;
+
+DROP TYPE IF EXISTS tuition_currency;
+
+COMMIT;
@@ -0,0 +1,186 @@
+-- 020_search_multilingual.sql
+-- Бағдарлама іздеуі: en/ru/de stemming, синонимдер сөздігі (CS ↔ Computer Science ↔ Информатика,
+-- қала экзонимдері) және pg_trgm арқылы қате терілген сұраулар ("computr science").
+
+BEGIN;
+
+CREATE EXTENSION IF NOT EXISTS pg_trgm;
+
+-- синонимдер: бір group_key ішіндегі барлық term өзара балама
+CREATE TABLE IF NOT EXISTS search_synonyms (
+  id         UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+  group_key  TEXT NOT NULL,
+  term       TEXT NOT NULL CHECK (length(trim(term)) > 0),
+  lang       TEXT,            -- en/ru/kk/de; NULL = кез келген
+  kind       TEXT NOT NULL DEFAULT 'term' CHECK (kind IN ('term','city','country')),
+  created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS uq_search_synonyms_term
+  ON search_synonyms(group_key, lower(term));
+CREATE INDEX IF NOT EXISTS idx_search_synonyms_group
+  ON search_synonyms(group_key);
+
+INSERT INTO search_synonyms(group_key, term, lang, kind) VALUES
+  -- fields
+  ('cs','CS','en','term'),
+  ('cs','Computer Science','en','term'),
+  ('cs','Computing','en','term'),
+  ('cs','Informatik','de','term'),
+  ('cs','Информатика','ru','term'),
+  ('cs','Компьютерные науки','ru','term'),
+  ('cs','Информатика және есептеу техникасы','kk','term'),
+  ('data','Data Science','en','term'),
+  ('data','Datenwissenschaft','de','term'),
+  ('data','Наука о данных','ru','term'),
+  ('data','Анализ данных','ru','term'),
+  ('data','Деректер ғылымы','kk','term'),
+  ('ai','AI','en','term'),
+  ('ai','Artificial Intelligence','en','term'),
+  ('ai','Künstliche Intelligenz','de','term'),
+  ('ai','Искусственный интеллект','ru','term'),
+  ('ai','Жасанды интеллект','kk','term'),
+  ('bus','Business Administration','en','term'),
+  ('bus','MBA','en','term'),
+  ('bus','BWL','de','term'),
+  ('bus','Betriebswirtschaftslehre','de','term'),
+  ('bus','Менеджмент','ru','term'),
+  ('bus','Бизнес-администрирование','ru','term'),
+  ('econ','Economics','en','term'),
+  ('econ','Volkswirtschaftslehre','de','term'),
+  ('econ','VWL','de','term'),
+  ('econ','Экономика','ru','term'),
+  ('mech','Mechanical Engineering','en','term'),
+  ('mech','Maschinenbau','de','term'),
+  ('mech','Машиностроение','ru','term'),
+  ('ee','Electrical Engineering','en','term'),
+  ('ee','Elektrotechnik','de','term'),
+  ('ee','Электротехника','ru','term'),
+  ('med','Medicine','en','term'),
+  ('med','Medizin','de','term'),
+  ('med','Медицина','ru','term'),
+  ('law','Law','en','term'),
+  ('law','Rechtswissenschaft','de','term'),
+  ('law','Юриспруденция','ru','term'),
+  ('law','Право','ru','term'),
+  ('math','Mathematics','en','term'),
+  ('math','Mathematik','de','term'),
+  ('math','Математика','ru','term'),
+  ('phys','Physics','en','term'),
+  ('phys','Physik','de','term'),
+  ('phys','Физика','ru','term'),
+
+  -- city exonyms
+  ('city:munich','Munich','en','city'),
+  ('city:munich','München','de','city'),
+  ('city:munich','Мюнхен','ru','city'),
+  ('city:berlin','Berlin','en','city'),
+  ('city:berlin','Берлин','ru','city'),
+  ('city:hamburg','Hamburg','en','city'),
+  ('city:hamburg','Гамбург','ru','city'),
+  ('city:stuttgart','Stuttgart','en','city'),
+  ('city:stuttgart','Штутгарт','ru','city'),
+  ('city:cologne','Cologne','en','city'),
+  ('city:cologne','Köln','de','city'),
+  ('city:cologne','Кёльн','ru','city'),
+  ('city:frankfurt','Frankfurt','en','city'),
+  ('city:frankfurt','Франкфурт','ru','city'),
+  ('city:heidelberg','Heidelberg','en','city'),
+  ('city:heidelberg','Гейдельберг','ru','city'),
+  ('city:vienna','Vienna','en','city'),
+  ('city:vienna','Wien','de','city'),
+  ('city:vienna','Вена','ru','city'),
+  ('city:prague','Prague','en','city'),
+  ('city:prague','Praha','cs','city'),
+  ('city:prague','Прага','ru','city'),
+  ('city:almaty','Almaty','en','city'),
+  ('city:almaty','Алматы','kk','city'),
+  ('city:astana','Astana','en','city'),
+  ('city:astana','Астана','kk','city'),
+
+  -- countries (universities.country_code құжатқа енеді)
+  ('country:de','DE',NULL,'country'),
+  ('country:de','Germany','en','country'),
+  ('country:de','Deutschland','de','country'),
+  ('country:de','Германия','ru','country'),
+  ('country:us','US',NULL,'country'),
+  ('country:us','USA','en','country'),
+  ('country:us','United States','en','country'),
+  ('country:us','США','ru','country'),
+  ('country:us','АҚШ','kk','country'),
+  ('country:kz','KZ',NULL,'country'),
+  ('country:kz','Kazakhstan','en','country'),
+  ('country:kz','Казахстан','ru','country'),
+  ('country:kz','Қазақстан','kk','country')
+ON CONFLICT DO NOTHING;
+
+-- search_synonym_terms returns every term of every group that q mentions
+-- (whole-word match, case-insensitive).
+CREATE OR REPLACE FUNCTION search_synonym_terms(q TEXT) RETURNS SETOF TEXT AS $$
+  SELECT DISTINCT s2.term
+  FROM search_synonyms s1
+  JOIN search_synonyms s2 ON s2.group_key = s1.group_key
+  WHERE lower(q) ~ (
+    '(^|[^[:alnum:]])' ||
+    regexp_replace(lower(s1.term), '([.^$*+?()\[\]{}|\\-])', '\\\1', 'g') ||
+    '($|[^[:alnum:]])'
+  )
+$$ LANGUAGE sql STABLE;
+
+-- programs_tsquery: q-дің simple/en/ru/de нұсқалары OR синонимдер (фраза ретінде).
+CREATE OR REPLACE FUNCTION programs_tsquery(q TEXT) RETURNS tsquery AS $$
+DECLARE
+  res tsquery;
+  t TEXT;
+BEGIN
+  res := plainto_tsquery('simple', q)
+      || plainto_tsquery('english', q)
+      || plainto_tsquery('russian', q)
+      || plainto_tsquery('german', q);
+  FOR t IN SELECT search_synonym_terms(q) LOOP
+    res := res || phraseto_tsquery('simple', t);
+  END LOOP;
+  RETURN res;
+END;
+$$ LANGUAGE plpgsql STABLE;
+
+-- trigram мәтіні (қате терілген сұраулар үшін)
+ALTER TABLE programs ADD COLUMN IF NOT EXISTS search_text TEXT;
+
+-- search_vector: title (A), field (B), university/city/country (C); simple + en/ru/de stemming
+CREATE OR REPLACE FUNCTION update_programs_search_vector() RETURNS TRIGGER AS $$
+DECLARE
+  uni_name TEXT;
+  uni_country TEXT;
+  uni_city TEXT;
+  head TEXT;
+  place TEXT;
+BEGIN
+  SELECT name, country_code, city INTO uni_name, uni_country, uni_city
+  FROM universities WHERE id = NEW.university_id;
+
+  head := coalesce(NEW.title,'');
+  place := coalesce(uni_name,'') || ' ' || coalesce(uni_city,'') || ' ' || coalesce(uni_country,'');
+
+  NEW.search_vector :=
+    setweight(to_tsvector('simple', head), 'A') ||
+    setweight(to_tsvector('english', head), 'A') ||
+    setweight(to_tsvector('russian', head), 'A') ||
+    setweight(to_tsvector('german', head), 'A') ||
+    setweight(to_tsvector('simple', coalesce(NEW.field,'') || ' ' || coalesce(NEW.language,'')), 'B') ||
+    setweight(to_tsvector('english', coalesce(NEW.field,'')), 'B') ||
+    setweight(to_tsvector('simple', place), 'C');
+
+  NEW.search_text := lower(head || ' ' || coalesce(NEW.field,'') || ' ' || place);
+
+  RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+-- бар жолдарды қайта индекстеу (trigger UPDATE OF title-да іске қосылады)
+UPDATE programs SET title = title;
+
+CREATE INDEX IF NOT EXISTS idx_programs_search_text_trgm
+  ON programs USING GIN (search_text gin_trgm_ops);
+
+COMMIT;
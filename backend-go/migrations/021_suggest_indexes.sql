@@ -0,0 +1,19 @@
+-- 021_suggest_indexes.sql
+-- GET /suggest: prefix/ILIKE іздеуге trigram индекстер (pg_trgm 020-да қосылған).
+
+BEGIN;
+
+CREATE INDEX IF NOT EXISTS idx_universities_name_trgm
+  ON universities USING GIN (name gin_trgm_ops);
+CREATE INDEX IF NOT EXISTS idx_universities_city_trgm
+  ON universities USING GIN (city gin_trgm_ops);
+CREATE INDEX IF NOT EXISTS idx_programs_title_trgm
+  ON programs USING GIN (title gin_trgm_ops);
+CREATE INDEX IF NOT EXISTS idx_programs_field_trgm
+  ON programs USING GIN (field gin_trgm_ops);
+
+-- popularity: соңғы 90 күндегі scores саны
+CREATE INDEX IF NOT EXISTS idx_scores_program_created
+  ON scores(program_id, created_at DESC);
+
+COMMIT;
@@ -0,0 +1,35 @@
+-- 022_program_deadlines.sql
+-- Бағдарлама мерзімдері (өтінім, грант, құжаттар) — GET /programs/:id.
+
+BEGIN;
+
+CREATE TABLE IF NOT EXISTS program_deadlines (
+  id          UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+  program_id  UUID NOT NULL REFERENCES programs(id) ON DELETE CASCADE,
+  source_id   UUID REFERENCES sources(id) ON DELETE SET NULL,
+
+  intake      TEXT NOT NULL,  -- "2026 winter", "2026 fall"
+  kind        TEXT NOT NULL DEFAULT 'application'
+              CHECK (kind IN ('application','scholarship','documents','enrollment')),
+  deadline    DATE NOT NULL,
+  note        TEXT,
+
+  created_at  TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+  updated_at  TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS uq_program_deadlines
+  ON program_deadlines(program_id, intake, kind);
+CREATE INDEX IF NOT EXISTS idx_program_deadlines_program_date
+  ON program_deadlines(program_id, deadline);
+
+DROP TRIGGER IF EXISTS trg_program_deadlines_updated_at ON program_deadlines;
+CREATE TRIGGER trg_program_deadlines_updated_at
+BEFORE UPDATE ON program_deadlines
+FOR EACH ROW EXECUTE FUNCTION set_updated_at();
+
+-- scores: соңғы ұпайды (user, program) бойынша жылдам алу
+CREATE INDEX IF NOT EXISTS idx_scores_profile_program_created
+  ON scores(profile_id, program_id, created_at DESC);
+
+COMMIT;
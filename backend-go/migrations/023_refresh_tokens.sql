@@ -0,0 +1,28 @@
+-- 023_refresh_tokens.sql
+-- Қысқа access JWT + айналмалы (rotating) refresh токендер.
+-- refresh_tokens.token_hash — sha256(token) hex; токеннің өзі DB-да сақталмайды.
+-- users.token_version — JWT "ver" claim; logout-all кезінде өседі, ескі access токендер жарамсыз болады.
+
+BEGIN;
+
+ALTER TABLE users
+  ADD COLUMN IF NOT EXISTS token_version INT NOT NULL DEFAULT 0;
+
+CREATE TABLE IF NOT EXISTS refresh_tokens (
+  id          UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+  user_id     UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+  token_hash  TEXT NOT NULL UNIQUE,
+  family_id   UUID NOT NULL,          -- бір login сессиясының айналу тізбегі
+  expires_at  TIMESTAMPTZ NOT NULL,
+  revoked_at  TIMESTAMPTZ,
+  replaced_by UUID REFERENCES refresh_tokens(id) ON DELETE SET NULL,
+  user_agent  TEXT,
+  ip          TEXT,
+  created_at  TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_refresh_tokens_user ON refresh_tokens(user_id);
+CREATE INDEX IF NOT EXISTS idx_refresh_tokens_family ON refresh_tokens(family_id);
+CREATE INDEX IF NOT EXISTS idx_refresh_tokens_expires ON refresh_tokens(expires_at);
+
+COMMIT;
@@ -0,0 +1,27 @@
+-- 024_email_tokens.sql
+-- Email растау және құпиясөзді қалпына келтіру.
+-- email_tokens.token_hash — sha256(token) hex (refresh_tokens сияқты); токен бір рет қолданылады (used_at).
+
+BEGIN;
+
+ALTER TABLE users
+  ADD COLUMN IF NOT EXISTS email_verified_at TIMESTAMPTZ;
+
+-- бұрыннан бар аккаунттар растау хатын алмаған: шектеу оларға тимеуі үшін тіркелген күнімен растаймыз
+UPDATE users SET email_verified_at = created_at WHERE email_verified_at IS NULL;
+
+CREATE TABLE IF NOT EXISTS email_tokens (
+  id          UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+  user_id     UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+  purpose     TEXT NOT NULL CHECK (purpose IN ('verify_email','password_reset')),
+  token_hash  TEXT NOT NULL UNIQUE,
+  email       TEXT NOT NULL,          -- жіберілген адрес; email өзгерсе токен жарамсыз
+  expires_at  TIMESTAMPTZ NOT NULL,
+  used_at     TIMESTAMPTZ,
+  created_at  TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_email_tokens_user_purpose ON email_tokens(user_id, purpose);
+CREATE INDEX IF NOT EXISTS idx_email_tokens_expires ON email_tokens(expires_at);
+
+COMMIT;
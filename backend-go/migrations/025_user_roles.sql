@@ -0,0 +1,24 @@
+-- 025_user_roles.sql
+-- Рөлдер: student (әдепкі), counselor, admin. JWT "role" claim-ына жазылады;
+-- рөл өзгергенде token_version өседі, сондықтан ескі токендер жарамсыз.
+-- counselor_students — кеңесшіге бекітілген студенттер (профильдерін оқи алады).
+
+BEGIN;
+
+ALTER TABLE users
+  ADD COLUMN IF NOT EXISTS role TEXT NOT NULL DEFAULT 'student'
+    CONSTRAINT users_role_check CHECK (role IN ('student','counselor','admin'));
+
+CREATE INDEX IF NOT EXISTS idx_users_role ON users(role) WHERE role <> 'student';
+
+CREATE TABLE IF NOT EXISTS counselor_students (
+  counselor_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+  student_id   UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+  created_at   TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+  PRIMARY KEY (counselor_id, student_id),
+  CHECK (counselor_id <> student_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_counselor_students_student ON counselor_students(student_id);
+
+COMMIT;
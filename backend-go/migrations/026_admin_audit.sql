@@ -0,0 +1,34 @@
+-- 026_admin_audit.sql
+-- Admin CRUD үшін:
+-- 1) requirements-ке created_at/updated_at (optimistic concurrency updated_at бойынша);
+-- 2) audit_log — кім, қашан, нені өзгертті (before/after JSONB). Auth оқиғалары да осында жазылады.
+
+BEGIN;
+
+ALTER TABLE requirements
+  ADD COLUMN IF NOT EXISTS created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+  ADD COLUMN IF NOT EXISTS updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW();
+
+DROP TRIGGER IF EXISTS trg_requirements_updated_at ON requirements;
+CREATE TRIGGER trg_requirements_updated_at
+BEFORE UPDATE ON requirements
+FOR EACH ROW EXECUTE FUNCTION set_updated_at();
+
+CREATE TABLE IF NOT EXISTS audit_log (
+  id          BIGSERIAL PRIMARY KEY,
+  actor_id    UUID REFERENCES users(id) ON DELETE SET NULL,   -- NULL: аноним (мысалы сәтсіз login)
+  action      TEXT NOT NULL,          -- create | update | delete | login_failed | login_locked ...
+  entity      TEXT,                   -- universities | programs | requirements | university_links | sources | users
+  entity_id   TEXT,
+  before      JSONB,
+  after       JSONB,
+  meta        JSONB,
+  ip          TEXT,
+  created_at  TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_audit_log_entity ON audit_log(entity, entity_id, created_at DESC);
+CREATE INDEX IF NOT EXISTS idx_audit_log_actor ON audit_log(actor_id, created_at DESC);
+CREATE INDEX IF NOT EXISTS idx_audit_log_action ON audit_log(action, created_at DESC);
+
+COMMIT;
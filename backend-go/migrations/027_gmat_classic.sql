@@ -0,0 +1,17 @@
+-- 027_gmat_classic.sql
+-- GMAT: тек Classic (200–800) шкаласы. GMAT → GRE concordance (internal/scoring/concordance.go)
+-- осы шкалаға құрылған; профиль, admin және requirements бір диапазонды қолданады.
+
+BEGIN;
+
+UPDATE profiles SET gmat = 800 WHERE gmat > 800;
+ALTER TABLE profiles DROP CONSTRAINT IF EXISTS profiles_gmat_check;
+ALTER TABLE profiles ADD CONSTRAINT profiles_gmat_check
+  CHECK (gmat IS NULL OR gmat BETWEEN 200 AND 800);
+
+UPDATE requirements SET min_gmat = 800 WHERE min_gmat > 800;
+ALTER TABLE requirements DROP CONSTRAINT IF EXISTS requirements_min_gmat_check;
+ALTER TABLE requirements ADD CONSTRAINT requirements_min_gmat_check
+  CHECK (min_gmat IS NULL OR min_gmat BETWEEN 200 AND 800);
+
+COMMIT;